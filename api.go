@@ -0,0 +1,185 @@
+package turso
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+//go:generate go run ./internal/fakegen -in api.go -out fakes.go
+
+// API is implemented by *Client and by FakeAPI. Code that depends on API
+// rather than *Client can be unit tested without a Platform API.
+type API interface {
+	ApiTokensAPI() ApiTokensAPI
+	BillingAPI() BillingAPI
+	DatabasesAPI() DatabasesAPI
+	FeedbackAPI() FeedbackAPI
+	GroupsAPI() GroupsAPI
+	InstancesAPI() InstancesAPI
+	InvoicesAPI() InvoicesAPI
+	LocationsAPI() LocationsAPI
+	OrganizationsAPI() OrganizationsAPI
+	PlansAPI() PlansAPI
+	SubscriptionsAPI() SubscriptionsAPI
+	TokensAPI() TokensAPI
+	UsersAPI() UsersAPI
+}
+
+// ApiTokensAPI is implemented by ApiTokensClient.
+type ApiTokensAPI interface {
+	List(ctx context.Context) ([]ApiToken, error)
+	Create(ctx context.Context, name string) (CreateApiToken, error)
+	Revoke(ctx context.Context, name string) error
+}
+
+// BillingAPI is implemented by BillingClient.
+type BillingAPI interface {
+	Portal(ctx context.Context) (Portal, error)
+	PortalForStripeId(ctx context.Context, stripeId string) (Portal, error)
+	HasPaymentMethod(ctx context.Context) (bool, error)
+	HasPaymentMethodWithStripeId(ctx context.Context, stripeId string) (bool, error)
+	CreateStripeCustomer(ctx context.Context, name string) (string, error)
+	GetBillingCustomer(ctx context.Context) (BillingCustomer, error)
+	UpdateBillingCustomer(ctx context.Context, customer BillingCustomer) error
+}
+
+// DatabasesAPI is implemented by DatabasesClient.
+type DatabasesAPI interface {
+	List(ctx context.Context) ([]Database, error)
+	Get(ctx context.Context, name string) (Database, error)
+	GetWithInstances(ctx context.Context, name string) (Database, error)
+	Delete(ctx context.Context, database string) error
+	Create(ctx context.Context, name, location, image, extensions, group string, schema string, isSchema bool, seed *DBSeed) (*CreateDatabaseResponse, error)
+	CreateWithOptions(ctx context.Context, opts CreateDatabaseOptions) (*CreatedDatabase, error)
+	Branch(ctx context.Context, source, target string, opts BranchOptions) (*DatabaseBranch, error)
+	WaitReady(ctx context.Context, name string, opts WaitReadyOptions) error
+	Seed(ctx context.Context, name string, dbFile *os.File) error
+	SeedReader(ctx context.Context, name string, upload Upload) error
+	UploadDump(ctx context.Context, dbFile *os.File) (string, error)
+	UploadDumpReader(ctx context.Context, upload Upload) (string, error)
+	ValidateSeed(ctx context.Context, dbFile *os.File) (*ValidationReport, error)
+	ValidateDump(ctx context.Context, dumpFile *os.File) (*ValidationReport, error)
+	Token(ctx context.Context, database string, expiration string, readOnly bool, permissions *PermissionsClaim) (string, error)
+	Rotate(ctx context.Context, database string) error
+	Update(ctx context.Context, database string, group bool) error
+	Stats(ctx context.Context, database string) (Stats, error)
+	Transfer(ctx context.Context, database, org string) error
+	Wakeup(ctx context.Context, database string) error
+	Usage(ctx context.Context, database string) (DbUsage, error)
+	UsageBetween(ctx context.Context, database string, from, to time.Time) (DbUsage, error)
+	UsageByLocation(ctx context.Context, database string, from, to time.Time) (map[string]Usage, error)
+	GetConfig(ctx context.Context, database string) (DatabaseConfig, error)
+	UpdateConfig(ctx context.Context, database string, config DatabaseConfig) error
+}
+
+// FeedbackAPI is implemented by FeedbackClient.
+type FeedbackAPI interface {
+	Submit(ctx context.Context, summary, feedback string) error
+}
+
+// GroupsAPI is implemented by GroupsClient.
+type GroupsAPI interface {
+	List(ctx context.Context) ([]Group, error)
+	Get(ctx context.Context, name string) (Group, error)
+	Delete(ctx context.Context, group string) error
+	Create(ctx context.Context, name, location, version string) error
+	Unarchive(ctx context.Context, name string) error
+	AddLocation(ctx context.Context, name, location string) error
+	RemoveLocation(ctx context.Context, name, location string) error
+	WaitLocation(ctx context.Context, name, location string) error
+	Token(ctx context.Context, group string, expiration string, readOnly bool, permissions *PermissionsClaim) (string, error)
+	Rotate(ctx context.Context, group string) error
+	Update(ctx context.Context, group string, version, extensions string) error
+	Transfer(ctx context.Context, group string, to string) error
+}
+
+// InstancesAPI is implemented by InstancesClient.
+type InstancesAPI interface {
+	List(ctx context.Context, db string) ([]Instance, error)
+	Delete(ctx context.Context, db, instance string) error
+	Create(ctx context.Context, dbName, location string) (*Instance, error)
+	Wait(ctx context.Context, db, instance string) error
+}
+
+// InvoicesAPI is implemented by InvoicesClient.
+type InvoicesAPI interface {
+	List(ctx context.Context) ([]Invoice, error)
+}
+
+// LocationsAPI is implemented by LocationsClient.
+type LocationsAPI interface {
+	List(ctx context.Context) (map[string]string, error)
+	Get(ctx context.Context, location string) (LocationResponse, error)
+	Closest(ctx context.Context) (string, error)
+	ClosestTo(ctx context.Context, latitude, longitude float64) (string, error)
+	Catalogue(ctx context.Context) (*Catalogue, error)
+}
+
+// OrganizationsAPI is implemented by OrganizationsClient.
+type OrganizationsAPI interface {
+	List(ctx context.Context) ([]Organization, error)
+	Create(ctx context.Context, name string, stripeId string, dryRun bool) (Organization, error)
+	Delete(ctx context.Context, slug string) error
+	Usage(ctx context.Context) (OrgUsage, error)
+	SetOverages(ctx context.Context, slug string, toggle bool) error
+	ListMembers(ctx context.Context) ([]Member, error)
+	AddMember(ctx context.Context, username, role string) error
+	InviteMember(ctx context.Context, email, role string) error
+	DeleteInvite(ctx context.Context, email string) error
+	ListInvites(ctx context.Context) ([]Invite, error)
+	RemoveMember(ctx context.Context, username string) error
+}
+
+// PlansAPI is implemented by PlansClient.
+type PlansAPI interface {
+	List(ctx context.Context) ([]Plan, error)
+}
+
+// SubscriptionsAPI is implemented by SubscriptionClient.
+type SubscriptionsAPI interface {
+	Get(ctx context.Context) (Subscription, error)
+	Update(ctx context.Context, plan, timeline string, overages *bool) error
+}
+
+// TokensAPI is implemented by TokensClient.
+type TokensAPI interface {
+	Validate(ctx context.Context, token string) (int64, error)
+	Invalidate(ctx context.Context) (int64, error)
+}
+
+// UsersAPI is implemented by UsersClient.
+type UsersAPI interface {
+	GetUser(ctx context.Context) (UserInfo, error)
+}
+
+var (
+	_ API              = (*Client)(nil)
+	_ ApiTokensAPI     = (*ApiTokensClient)(nil)
+	_ BillingAPI       = (*BillingClient)(nil)
+	_ DatabasesAPI     = (*DatabasesClient)(nil)
+	_ FeedbackAPI      = (*FeedbackClient)(nil)
+	_ GroupsAPI        = (*GroupsClient)(nil)
+	_ InstancesAPI     = (*InstancesClient)(nil)
+	_ InvoicesAPI      = (*InvoicesClient)(nil)
+	_ LocationsAPI     = (*LocationsClient)(nil)
+	_ OrganizationsAPI = (*OrganizationsClient)(nil)
+	_ PlansAPI         = (*PlansClient)(nil)
+	_ SubscriptionsAPI = (*SubscriptionClient)(nil)
+	_ TokensAPI        = (*TokensClient)(nil)
+	_ UsersAPI         = (*UsersClient)(nil)
+)
+
+func (c *Client) ApiTokensAPI() ApiTokensAPI         { return c.ApiTokens }
+func (c *Client) BillingAPI() BillingAPI             { return c.Billing }
+func (c *Client) DatabasesAPI() DatabasesAPI         { return c.Databases }
+func (c *Client) FeedbackAPI() FeedbackAPI           { return c.Feedback }
+func (c *Client) GroupsAPI() GroupsAPI               { return c.Groups }
+func (c *Client) InstancesAPI() InstancesAPI         { return c.Instances }
+func (c *Client) InvoicesAPI() InvoicesAPI           { return c.Invoices }
+func (c *Client) LocationsAPI() LocationsAPI         { return c.Locations }
+func (c *Client) OrganizationsAPI() OrganizationsAPI { return c.Organizations }
+func (c *Client) PlansAPI() PlansAPI                 { return c.Plans }
+func (c *Client) SubscriptionsAPI() SubscriptionsAPI { return c.Subscriptions }
+func (c *Client) TokensAPI() TokensAPI               { return c.Tokens }
+func (c *Client) UsersAPI() UsersAPI                 { return c.Users }
@@ -3,6 +3,7 @@ package turso
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 )
 
@@ -16,14 +17,15 @@ type ApiToken struct {
 type ApiTokensClient client
 
 func (c *ApiTokensClient) List(ctx context.Context) ([]ApiToken, error) {
+	ctx = WithOperation(ctx, "api_tokens.list")
 	res, err := c.client.Get(ctx, "/v1/auth/api-tokens", nil)
 	if err != nil {
-		return []ApiToken{}, fmt.Errorf("failed to get api tokens list: %s", err)
+		return []ApiToken{}, fmt.Errorf("failed to get api tokens list: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get api tokens list: %s", res.Status)
+		return nil, fmt.Errorf("failed to get api tokens list: %w", parseResponseError(res))
 	}
 
 	type ListResponse struct {
@@ -39,12 +41,18 @@ type CreateApiToken struct {
 	Value string `json:"value"`
 }
 
+// LogValue keeps the token value out of structured logs.
+func (t CreateApiToken) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("name", t.Name), slog.String("id", t.ID), slog.String("value", redacted))
+}
+
 func (c *ApiTokensClient) Create(ctx context.Context, name string) (CreateApiToken, error) {
+	ctx = WithOperation(ctx, "api_tokens.create")
 	url := fmt.Sprintf("/v2/auth/api-tokens/%s", name)
 
 	res, err := c.client.Post(ctx, url, nil)
 	if err != nil {
-		return CreateApiToken{}, fmt.Errorf("failed to create token: %s", err)
+		return CreateApiToken{}, fmt.Errorf("failed to create token: %w", err)
 	}
 	defer res.Body.Close()
 
@@ -65,11 +73,12 @@ func (c *ApiTokensClient) Create(ctx context.Context, name string) (CreateApiTok
 }
 
 func (c *ApiTokensClient) Revoke(ctx context.Context, name string) error {
+	ctx = WithOperation(ctx, "api_tokens.revoke")
 	url := fmt.Sprintf("/v1/auth/api-tokens/%s", name)
 
 	res, err := c.client.Delete(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to revoke API token: %s", err)
+		return fmt.Errorf("failed to revoke API token: %w", err)
 	}
 	defer res.Body.Close()
 
@@ -0,0 +1,37 @@
+package turso_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alehechka/turso-go"
+)
+
+func provision(ctx context.Context, api turso.API, name string) error {
+	if err := api.GroupsAPI().Create(ctx, name, "ams", "latest"); err != nil {
+		return err
+	}
+	_, err := api.DatabasesAPI().Create(ctx, name, "", "", "", name, "", false, nil)
+	return err
+}
+
+func Test_FakeAPI_RecordsCalls(t *testing.T) {
+	fake := turso.NewFakeAPI()
+	fake.Databases.CreateFunc = func(ctx context.Context, name, location, image, extensions, group, schema string, isSchema bool, seed *turso.DBSeed) (*turso.CreateDatabaseResponse, error) {
+		return nil, turso.ErrConflict
+	}
+
+	err := provision(context.TODO(), fake, "my-db")
+	if !errors.Is(err, turso.ErrConflict) {
+		t.Fatalf("expected stubbed error, got: %v", err)
+	}
+
+	calls := fake.Groups.CallsTo("Create")
+	if len(calls) != 1 || calls[0].Args[0] != "my-db" || calls[0].Args[1] != "ams" {
+		t.Fatalf("unexpected group calls: %+v", calls)
+	}
+	if calls := fake.Databases.Calls(); len(calls) != 1 || calls[0].Method != "Create" {
+		t.Fatalf("unexpected database calls: %+v", calls)
+	}
+}
@@ -13,10 +13,8 @@ type Portal struct {
 }
 
 func (c *BillingClient) Portal(ctx context.Context) (Portal, error) {
-	prefix := "/v1"
-	if c.client.Org != "" {
-		prefix = "/v1/organizations/" + c.client.Org
-	}
+	ctx = WithOperation(ctx, "billing.portal")
+	prefix := c.client.orgPrefix(ctx)
 
 	r, err := c.client.Post(ctx, prefix+"/billing/portal", nil)
 	if err != nil {
@@ -25,7 +23,7 @@ func (c *BillingClient) Portal(ctx context.Context) (Portal, error) {
 	defer r.Body.Close()
 
 	if r.StatusCode != 200 {
-		return Portal{}, fmt.Errorf("failed to get billing portal with status %s: %v", r.Status, parseResponseError(r))
+		return Portal{}, fmt.Errorf("failed to get billing portal: %w", parseResponseError(r))
 	}
 
 	resp, err := unmarshal[struct{ Portal Portal }](r)
@@ -33,6 +31,7 @@ func (c *BillingClient) Portal(ctx context.Context) (Portal, error) {
 }
 
 func (c *BillingClient) PortalForStripeId(ctx context.Context, stripeId string) (Portal, error) {
+	ctx = WithOperation(ctx, "billing.portal_for_stripe_id")
 	prefix := "/v1"
 	type Body struct {
 		StripeID string `json:"stripe_id"`
@@ -50,7 +49,7 @@ func (c *BillingClient) PortalForStripeId(ctx context.Context, stripeId string)
 	defer r.Body.Close()
 
 	if r.StatusCode != 200 {
-		return Portal{}, fmt.Errorf("failed to get billing portal with status %s: %v", r.Status, parseResponseError(r))
+		return Portal{}, fmt.Errorf("failed to get billing portal: %w", parseResponseError(r))
 	}
 
 	resp, err := unmarshal[struct{ Portal Portal }](r)
@@ -58,10 +57,8 @@ func (c *BillingClient) PortalForStripeId(ctx context.Context, stripeId string)
 }
 
 func (c *BillingClient) HasPaymentMethod(ctx context.Context) (bool, error) {
-	prefix := "/v1"
-	if c.client.Org != "" {
-		prefix = "/v1/organizations/" + c.client.Org
-	}
+	ctx = WithOperation(ctx, "billing.has_payment_method")
+	prefix := c.client.orgPrefix(ctx)
 	r, err := c.client.Get(ctx, prefix+"/billing/payment-methods", nil)
 	if err != nil {
 		return false, fmt.Errorf("failed to get database usage: %w", err)
@@ -69,7 +66,7 @@ func (c *BillingClient) HasPaymentMethod(ctx context.Context) (bool, error) {
 	defer r.Body.Close()
 
 	if r.StatusCode != 200 {
-		return false, fmt.Errorf("failed to check payment method with status %s: %v", r.Status, parseResponseError(r))
+		return false, fmt.Errorf("failed to check payment method: %w", parseResponseError(r))
 	}
 
 	resp, err := unmarshal[struct{ Exists bool }](r)
@@ -77,6 +74,7 @@ func (c *BillingClient) HasPaymentMethod(ctx context.Context) (bool, error) {
 }
 
 func (c *BillingClient) HasPaymentMethodWithStripeId(ctx context.Context, stripeId string) (bool, error) {
+	ctx = WithOperation(ctx, "billing.has_payment_method_with_stripe_id")
 	prefix := "/v1"
 	r, err := c.client.Get(ctx, fmt.Sprintf("%s/billing/payment-methods?stripe_id=%s", prefix, stripeId), nil)
 	if err != nil {
@@ -85,7 +83,7 @@ func (c *BillingClient) HasPaymentMethodWithStripeId(ctx context.Context, stripe
 	defer r.Body.Close()
 
 	if r.StatusCode != 200 {
-		return false, fmt.Errorf("failed to check payment method with status %s: %v", r.Status, parseResponseError(r))
+		return false, fmt.Errorf("failed to check payment method: %w", parseResponseError(r))
 	}
 
 	resp, err := unmarshal[struct{ Exists bool }](r)
@@ -93,6 +91,7 @@ func (c *BillingClient) HasPaymentMethodWithStripeId(ctx context.Context, stripe
 }
 
 func (c *BillingClient) CreateStripeCustomer(ctx context.Context, name string) (string, error) {
+	ctx = WithOperation(ctx, "billing.create_stripe_customer")
 	prefix := "/v1"
 	type Body struct{ Name string }
 	body, err := marshal(Body{name})
@@ -107,7 +106,7 @@ func (c *BillingClient) CreateStripeCustomer(ctx context.Context, name string) (
 	defer r.Body.Close()
 
 	if r.StatusCode != 200 {
-		return "", fmt.Errorf("failed to create stripe customer with status %s: %v", r.Status, parseResponseError(r))
+		return "", fmt.Errorf("failed to create stripe customer: %w", parseResponseError(r))
 	}
 
 	resp, err := unmarshal[struct{ StripeCustomerId string }](r)
@@ -135,10 +134,8 @@ type BillingCustomer struct {
 }
 
 func (c *BillingClient) GetBillingCustomer(ctx context.Context) (BillingCustomer, error) {
-	prefix := "/v1"
-	if c.client.Org != "" {
-		prefix = "/v1/organizations/" + c.client.Org
-	}
+	ctx = WithOperation(ctx, "billing.get_billing_customer")
+	prefix := c.client.orgPrefix(ctx)
 	r, err := c.client.Get(ctx, prefix+"/billing/customer", nil)
 	if err != nil {
 		return BillingCustomer{}, fmt.Errorf("failed to get billing customer: %w", err)
@@ -146,7 +143,7 @@ func (c *BillingClient) GetBillingCustomer(ctx context.Context) (BillingCustomer
 	defer r.Body.Close()
 
 	if r.StatusCode != 200 {
-		return BillingCustomer{}, fmt.Errorf("failed to get billing customer with status %s: %v", r.Status, parseResponseError(r))
+		return BillingCustomer{}, fmt.Errorf("failed to get billing customer: %w", parseResponseError(r))
 	}
 
 	resp, err := unmarshal[BillingCustomer](r)
@@ -154,10 +151,8 @@ func (c *BillingClient) GetBillingCustomer(ctx context.Context) (BillingCustomer
 }
 
 func (c *BillingClient) UpdateBillingCustomer(ctx context.Context, customer BillingCustomer) error {
-	prefix := "/v1"
-	if c.client.Org != "" {
-		prefix = "/v1/organizations/" + c.client.Org
-	}
+	ctx = WithOperation(ctx, "billing.update_billing_customer")
+	prefix := c.client.orgPrefix(ctx)
 	body, err := marshal(customer)
 	if err != nil {
 		return fmt.Errorf("could not serialize request body: %w", err)
@@ -169,7 +164,7 @@ func (c *BillingClient) UpdateBillingCustomer(ctx context.Context, customer Bill
 	defer r.Body.Close()
 
 	if r.StatusCode != 200 {
-		return fmt.Errorf("failed to update billing customer with status %s: %v", r.Status, parseResponseError(r))
+		return fmt.Errorf("failed to update billing customer: %w", parseResponseError(r))
 	}
 
 	return nil
@@ -0,0 +1,87 @@
+package turso
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BranchOptions configures a branch created with DatabasesClient.Branch.
+type BranchOptions struct {
+	// Timestamp restores the source database as it was at that time. The zero
+	// value copies its current state.
+	Timestamp time.Time
+	// Group is the group the branch is created in. Defaults to the group of
+	// the source database.
+	Group string
+	// Token mints a token for the branch, see DatabasesClient.Token.
+	Token bool
+	// TokenExpiration is the expiration of the token, e.g. "7d". Defaults to
+	// "never".
+	TokenExpiration string
+	// TokenReadOnly restricts the token to reads.
+	TokenReadOnly bool
+	// Wait configures the wait until every instance of the branch is ready,
+	// see DatabasesClient.WaitReady.
+	Wait WaitReadyOptions
+}
+
+// DatabaseBranch is a database created with DatabasesClient.Branch.
+type DatabaseBranch struct {
+	Database Database
+	// URL is the libsql:// URL to connect to the branch.
+	URL string
+	// Token is set when BranchOptions.Token is.
+	Token string
+}
+
+// Branch creates the database target as a copy of the database source,
+// optionally at a point in time, and waits until every instance of target is
+// ready before returning it. Like CreateWithOptions, target is deleted on a
+// best effort basis when a step fails after it was created.
+//
+//	branch, err := client.Databases.Branch(ctx, "production", "pr-1234", turso.BranchOptions{Token: true})
+func (c *DatabasesClient) Branch(ctx context.Context, source, target string, opts BranchOptions) (*DatabaseBranch, error) {
+	ctx = WithOperation(ctx, "databases.branch")
+	if source == "" || target == "" {
+		return nil, fmt.Errorf("%w: source and target databases are required", ErrInvalidOptions)
+	}
+	if !opts.Timestamp.IsZero() && opts.Timestamp.After(time.Now()) {
+		return nil, fmt.Errorf("%w: timestamp %s is in the future", ErrInvalidOptions, opts.Timestamp.Format(time.RFC3339))
+	}
+
+	group := opts.Group
+	if group == "" {
+		db, err := c.Get(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to branch database %s: %w", source, err)
+		}
+		group = db.Group
+	}
+
+	created, err := c.CreateWithOptions(ctx, CreateDatabaseOptions{
+		Name:  target,
+		Group: group,
+		Seed:  SeedFromDatabase(source, opts.Timestamp),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to branch database %s: %w", source, err)
+	}
+
+	if err := c.WaitReady(ctx, target, opts.Wait); err != nil {
+		return nil, c.deleteAfterFailure(ctx, target, fmt.Errorf("failed to wait for branch %s: %w", target, err))
+	}
+
+	branch := &DatabaseBranch{Database: created.Database, URL: created.URL}
+	if opts.Token {
+		expiration := opts.TokenExpiration
+		if expiration == "" {
+			expiration = "never"
+		}
+		branch.Token, err = c.Token(ctx, target, expiration, opts.TokenReadOnly, nil)
+		if err != nil {
+			return nil, c.deleteAfterFailure(ctx, target, err)
+		}
+	}
+	return branch, nil
+}
@@ -0,0 +1,72 @@
+package turso_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/tursotest"
+)
+
+func Test_Databases_Branch(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+	ctx := context.TODO()
+
+	if err := client.Groups.Create(ctx, "production", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Groups.AddLocation(ctx, "production", "fra"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.CreateWithOptions(ctx, turso.CreateDatabaseOptions{Name: "prod", Group: "production"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ready := 0
+	branch, err := client.Databases.Branch(ctx, "prod", "pr-1234", turso.BranchOptions{
+		Timestamp: time.Now().Add(-time.Hour),
+		Token:     true,
+		Wait: turso.WaitReadyOptions{
+			Timeout: time.Minute,
+			Progress: func(p turso.InstanceProgress) {
+				if p.Ready {
+					ready++
+				}
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branch.Database.Name != "pr-1234" || branch.Database.Group != "production" {
+		t.Fatalf("unexpected branch: %+v", branch.Database)
+	}
+	if branch.Token == "" || !strings.HasPrefix(branch.URL, "libsql://") {
+		t.Fatalf("expected a token and url, got: %+v", branch)
+	}
+
+	waits := 0
+	for _, request := range server.Requests() {
+		if strings.HasPrefix(request.Path, "/v1/organizations/tursotest/databases/pr-1234/instances/") && strings.HasSuffix(request.Path, "/wait") {
+			waits++
+		}
+	}
+	if waits != 2 {
+		t.Fatalf("expected every instance to be waited for, got %d waits", waits)
+	}
+	if ready != 2 {
+		t.Fatalf("expected progress to report every instance ready, got %d", ready)
+	}
+
+	if _, err := client.Databases.Branch(ctx, "missing", "pr-5678", turso.BranchOptions{}); !errors.Is(err, turso.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	if _, err := client.Databases.Branch(ctx, "prod", "pr-5678", turso.BranchOptions{Timestamp: time.Now().Add(time.Hour)}); !errors.Is(err, turso.ErrInvalidOptions) {
+		t.Fatalf("expected ErrInvalidOptions, got: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package turso
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type callConfig struct {
+	query url.Values
+}
+
+// CallOption configures a request sent with Call.
+type CallOption interface {
+	apply(*callConfig)
+}
+
+type withQuery struct {
+	query url.Values
+}
+
+// WithQuery adds query to the URL of the request.
+func WithQuery(query url.Values) CallOption {
+	return &withQuery{query: query}
+}
+
+func (o *withQuery) apply(config *callConfig) {
+	for key, values := range o.query {
+		for _, value := range values {
+			config.query.Add(key, value)
+		}
+	}
+}
+
+// Call sends a request to an endpoint of the Platform API that has no
+// dedicated method, with the same retries, middleware, logging and error
+// handling, and decodes its JSON response into Resp.
+//
+// Paths starting with a slash are used as is, e.g. "/v1/locations". Other
+// paths are relative to the organization the call operates on, so
+// "databases/my-db/stats" becomes "/v1/organizations/{org}/databases/my-db/stats".
+// A non-nil body is encoded as JSON, unless it is an io.Reader or a []byte
+// which are sent as is. Responses with a non 2xx status are returned as an
+// *APIError. An empty response body leaves Resp as its zero value.
+//
+//	stats, err := turso.Call[turso.Stats](ctx, client, http.MethodGet, "databases/my-db/stats", nil)
+func Call[Resp any](ctx context.Context, c *Client, method, path string, body any, opts ...CallOption) (Resp, error) {
+	var resp Resp
+	if OperationFromContext(ctx) == "" {
+		ctx = WithOperation(ctx, "call")
+	}
+
+	config := &callConfig{query: url.Values{}}
+	for _, opt := range opts {
+		opt.apply(config)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = c.orgPrefix(ctx) + "/" + path
+	}
+	if len(config.query) > 0 {
+		separator := "?"
+		if strings.Contains(path, "?") {
+			separator = "&"
+		}
+		path += separator + config.query.Encode()
+	}
+
+	reqBody, err := encodeCallBody(body)
+	if err != nil {
+		return resp, fmt.Errorf("could not serialize request body: %w", err)
+	}
+
+	res, err := c.Do(ctx, method, path, reqBody)
+	if err != nil {
+		return resp, fmt.Errorf("failed to call %s %s: %w", method, path, err)
+	}
+	defer res.Body.Close()
+
+	if strings.HasPrefix(path, "/v1/organizations/") && c.isNotMemberErr(res) {
+		return resp, c.notMemberErr(res)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return resp, fmt.Errorf("failed to call %s %s: %w", method, path, parseResponseError(res))
+	}
+
+	if res.StatusCode == http.StatusNoContent {
+		return resp, nil
+	}
+	resp, err = unmarshal[Resp](res)
+	if errors.Is(err, io.EOF) {
+		return resp, nil
+	}
+	return resp, err
+}
+
+func encodeCallBody(body any) (io.Reader, error) {
+	switch body := body.(type) {
+	case nil:
+		return nil, nil
+	case io.Reader:
+		return body, nil
+	case []byte:
+		return bytes.NewReader(body), nil
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
@@ -0,0 +1,57 @@
+package turso_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/tursotest"
+)
+
+func Test_Call_ScopesPathsAndDecodesResponses(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+	ctx := context.TODO()
+
+	type group struct {
+		Group turso.Group `json:"group"`
+	}
+	created, err := turso.Call[group](ctx, client, http.MethodPost, "groups", map[string]string{"name": "default", "location": "ams"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Group.Name != "default" || created.Group.Primary != "ams" {
+		t.Fatalf("unexpected group: %+v", created.Group)
+	}
+
+	locations, err := turso.Call[turso.LocationsResponse](ctx, client, http.MethodGet, "/v1/locations", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if locations.Locations["ams"] == "" {
+		t.Fatalf("expected locations, got: %+v", locations)
+	}
+
+	_, err = turso.Call[struct{}](ctx, client, http.MethodGet, "databases", nil, turso.WithQuery(url.Values{"group": {"default"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := server.Requests()
+	if last := requests[len(requests)-1]; last.Path != "/v1/organizations/tursotest/databases" || last.Query != "group=default" {
+		t.Fatalf("unexpected request: %+v", last)
+	}
+
+	_, err = turso.Call[turso.Stats](ctx, client, http.MethodGet, "databases/missing/stats", nil)
+	if !errors.Is(err, turso.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+
+	_, err = turso.Call[struct{}](turso.WithOrganization(ctx, "other"), client, http.MethodGet, "groups", nil)
+	if !errors.Is(err, turso.ErrNotMember) {
+		t.Fatalf("expected ErrNotMember, got: %v", err)
+	}
+}
@@ -0,0 +1,390 @@
+// Package cassette records Platform API interactions to golden files and
+// replays them, so client code can be tested deterministically without
+// network access.
+//
+// Record once against the real API, then replay the golden file in CI:
+//
+//	rec, err := cassette.New("testdata/databases.json", cassette.ModeRecord)
+//	client, err := turso.New(token, org, turso.WithHTTPClient(rec.Client()))
+//	// ... exercise the client ...
+//	err = rec.Save()
+//
+// Authorization headers are dropped and JWTs found in headers or bodies are
+// replaced before anything is written, so golden files are safe to commit. In
+// ModeReplay, requests that do not match a recorded interaction fail with
+// ErrUnmatched.
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Redacted replaces scrubbed secrets in golden files.
+const Redacted = "REDACTED"
+
+// ErrUnmatched is returned in ModeReplay for requests that match no recorded
+// interaction.
+var ErrUnmatched = errors.New("cassette: no recorded interaction matches request")
+
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+// Mode selects whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the golden file without sending
+	// requests.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests with the underlying transport and records
+	// them, overwriting the golden file on Save.
+	ModeRecord
+)
+
+// Cassette is the content of a golden file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a recorded body. It is written as a string when it is valid UTF-8
+// and as base64 otherwise, e.g. for uploaded database files.
+type Body []byte
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = Body(s)
+		return nil
+	}
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	*b = decoded
+	return err
+}
+
+// Matcher reports whether r matches the recorded request.
+type Matcher func(r *http.Request, body []byte, recorded Request) bool
+
+// DefaultMatcher matches requests by method, path, query and body. The host
+// is ignored so recordings can be replayed against any base URL. Multipart
+// bodies, e.g. uploads, are matched by their parts, since their boundaries
+// change with every request.
+func DefaultMatcher(r *http.Request, body []byte, recorded Request) bool {
+	if r.Method != recorded.Method {
+		return false
+	}
+	u, err := r.URL.Parse(recorded.URL)
+	if err != nil || u.Path != r.URL.Path || u.Query().Encode() != r.URL.Query().Encode() {
+		return false
+	}
+	if boundary, ok := multipartBoundary(r.Header); ok {
+		recordedBoundary, ok := multipartBoundary(recorded.Header)
+		if !ok {
+			return false
+		}
+		return equalParts(scrub(body), boundary, recorded.Body, recordedBoundary)
+	}
+	return bytes.Equal(scrub(body), recorded.Body)
+}
+
+// multipartBoundary returns the boundary of a multipart/form-data body.
+func multipartBoundary(header http.Header) (string, bool) {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return "", false
+	}
+	return params["boundary"], true
+}
+
+// part is a part of a multipart body.
+type part struct {
+	name     string
+	fileName string
+	content  []byte
+}
+
+// equalParts reports whether two multipart bodies have the same parts, in
+// the same order.
+func equalParts(a []byte, aBoundary string, b []byte, bBoundary string) bool {
+	aParts, err := readParts(a, aBoundary)
+	if err != nil {
+		return false
+	}
+	bParts, err := readParts(b, bBoundary)
+	if err != nil || len(aParts) != len(bParts) {
+		return false
+	}
+	for i := range aParts {
+		if aParts[i].name != bParts[i].name || aParts[i].fileName != bParts[i].fileName || !bytes.Equal(aParts[i].content, bParts[i].content) {
+			return false
+		}
+	}
+	return true
+}
+
+func readParts(body []byte, boundary string) ([]part, error) {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	var parts []part
+	for {
+		p, err := reader.NextPart()
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(p)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part{name: p.FormName(), fileName: p.FileName(), content: content})
+	}
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithTransport sets the transport used to send requests in ModeRecord.
+// Defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithMatcher replaces DefaultMatcher.
+func WithMatcher(matcher Matcher) Option {
+	return func(r *Recorder) {
+		r.matcher = matcher
+	}
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	matcher   Matcher
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New returns a Recorder backed by the golden file at path. In ModeReplay the
+// file is loaded and must exist.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		matcher:   DefaultMatcher,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Client returns an HTTP client using the Recorder as transport, to be passed
+// to turso.WithHTTPClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the interactions recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Unused returns the loaded interactions that have not been replayed yet.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+// Save writes the recorded interactions to the golden file. It does nothing
+// in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to serialize cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matcher(req, body, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+		recorded := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrUnmatched, req.Method, req.URL.RequestURI())
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: scrubHeader(req.Header),
+			Body:   scrub(body),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       scrub(respBody),
+		},
+	})
+	return resp, nil
+}
+
+// readRequestBody reads the body of req and replaces it so it can still be
+// sent.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func scrub(body []byte) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+	return jwtPattern.ReplaceAll(body, []byte(Redacted))
+}
+
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := make(http.Header, len(header))
+	for key, values := range header {
+		if strings.EqualFold(key, "Authorization") || strings.EqualFold(key, "Cookie") || strings.EqualFold(key, "Set-Cookie") {
+			continue
+		}
+		for _, value := range values {
+			scrubbed.Add(key, jwtPattern.ReplaceAllString(value, Redacted))
+		}
+	}
+	return scrubbed
+}
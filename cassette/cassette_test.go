@@ -0,0 +1,111 @@
+package cassette_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/cassette"
+	"github.com/alehechka/turso-go/tursotest"
+)
+
+func Test_Cassette_RecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "groups.json")
+	ctx := context.TODO()
+
+	server := tursotest.NewServer()
+	rec, err := cassette.New(path, cassette.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := server.Client(t, turso.WithHTTPClient(rec.Client()))
+	if err := client.Groups.Create(ctx, "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	token, err := client.Groups.Token(ctx, "default", "never", false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), tursotest.DefaultToken) || strings.Contains(string(data), token) {
+		t.Fatalf("expected secrets to be scrubbed, got: %s", data)
+	}
+
+	replay, err := cassette.New(path, cassette.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err = turso.New("other-token", tursotest.DefaultOrg, turso.WithBaseUrl("http://replay.invalid"), turso.WithHTTPClient(replay.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Groups.Create(ctx, "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := client.Groups.Token(ctx, "default", "never", false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed != cassette.Redacted {
+		t.Fatalf("expected scrubbed token to be replayed, got: %q", replayed)
+	}
+	if unused := replay.Unused(); len(unused) != 0 {
+		t.Fatalf("expected every interaction to be replayed, got %d unused", len(unused))
+	}
+
+	if _, err := client.Groups.List(ctx); !errors.Is(err, cassette.ErrUnmatched) {
+		t.Fatalf("expected unmatched request to fail, got: %v", err)
+	}
+}
+
+func Test_Cassette_ReplayUpload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload.json")
+	ctx := context.TODO()
+	dump := "CREATE TABLE t (id INTEGER);\nINSERT INTO t VALUES (1);\n"
+
+	server := tursotest.NewServer()
+	rec, err := cassette.New(path, cassette.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := server.Client(t, turso.WithHTTPClient(rec.Client()))
+	recorded, err := client.Databases.UploadDumpReader(ctx, turso.Upload{Reader: strings.NewReader(dump), Name: "dump.sql"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	replay, err := cassette.New(path, cassette.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err = turso.New("other-token", tursotest.DefaultOrg, turso.WithBaseUrl("http://replay.invalid"), turso.WithHTTPClient(replay.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.UploadDumpReader(ctx, turso.Upload{Reader: strings.NewReader("DROP TABLE t;\n"), Name: "dump.sql"}); !errors.Is(err, cassette.ErrUnmatched) {
+		t.Fatalf("expected a different upload not to match, got: %v", err)
+	}
+	replayed, err := client.Databases.UploadDumpReader(ctx, turso.Upload{Reader: strings.NewReader(dump), Name: "dump.sql"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed != recorded {
+		t.Fatalf("expected %q to be replayed, got: %q", recorded, replayed)
+	}
+}
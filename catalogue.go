@@ -0,0 +1,200 @@
+package turso
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Continent is the continent a location is in.
+type Continent string
+
+const (
+	Africa       Continent = "Africa"
+	Asia         Continent = "Asia"
+	Europe       Continent = "Europe"
+	NorthAmerica Continent = "North America"
+	Oceania      Continent = "Oceania"
+	SouthAmerica Continent = "South America"
+)
+
+// Coordinates are a latitude and longitude in decimal degrees.
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// earthRadius is the mean radius of the Earth in kilometers.
+const earthRadius = 6371.0
+
+// DistanceTo returns the great-circle distance in kilometers between c and to.
+func (c Coordinates) DistanceTo(to Coordinates) float64 {
+	toRadians := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRadians(to.Latitude - c.Latitude)
+	dLon := toRadians(to.Longitude - c.Longitude)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(c.Latitude))*math.Cos(toRadians(to.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+//go:embed locations.json
+var locationsTable []byte
+
+// Catalogue describes locations with their city, country, continent and
+// coordinates, to pick locations programmatically, e.g. the replicas to add to
+// a group. The locations it returns are copies that can be modified freely.
+type Catalogue struct {
+	locations map[string]Location
+}
+
+// knownLocations parses the embedded locations table on first use. The table
+// is checked by the tests, so an error cannot happen in a release.
+var knownLocations = sync.OnceValue(func() []Location {
+	var locations []Location
+	json.Unmarshal(locationsTable, &locations)
+	return locations
+})
+
+// DefaultCatalogue returns the catalogue of the locations known to the SDK.
+// Use LocationsClient.Catalogue to get the locations currently offered by the
+// API instead.
+func DefaultCatalogue() *Catalogue {
+	locations := knownLocations()
+	c := &Catalogue{locations: make(map[string]Location, len(locations))}
+	for _, location := range locations {
+		c.locations[location.Code] = location.clone()
+	}
+	return c
+}
+
+// clone returns a copy of l that does not share its coordinates.
+func (l Location) clone() Location {
+	if l.Coordinates != nil {
+		coords := *l.Coordinates
+		l.Coordinates = &coords
+	}
+	return l
+}
+
+// NewCatalogue returns a catalogue of the given locations, keyed by code with
+// their description as value as returned by LocationsClient.List. Locations
+// known to the SDK are completed with their city, country, continent and
+// coordinates. The city and country of others are taken from their
+// description, and they have no coordinates.
+func NewCatalogue(descriptions map[string]string) *Catalogue {
+	known := DefaultCatalogue()
+	c := &Catalogue{locations: make(map[string]Location, len(descriptions))}
+	for code, description := range descriptions {
+		location, ok := known.locations[code]
+		if !ok {
+			location = Location{Code: code}
+			city, country, _ := strings.Cut(description, ",")
+			location.City, location.Country = strings.TrimSpace(city), strings.TrimSpace(country)
+		}
+		location.Description = description
+		c.locations[code] = location
+	}
+	return c
+}
+
+// Get returns the location with the given code.
+func (c *Catalogue) Get(code string) (Location, bool) {
+	location, ok := c.locations[code]
+	return location.clone(), ok
+}
+
+// Locations returns every location of the catalogue sorted by code.
+func (c *Catalogue) Locations() []Location {
+	locations := make([]Location, 0, len(c.locations))
+	for _, location := range c.locations {
+		locations = append(locations, location.clone())
+	}
+	sort.Slice(locations, func(i, j int) bool { return locations[i].Code < locations[j].Code })
+	return locations
+}
+
+// Distance returns the great-circle distance in kilometers between the
+// locations with the given codes.
+func (c *Catalogue) Distance(from, to string) (float64, error) {
+	fromCoords, err := c.coordinates(from)
+	if err != nil {
+		return 0, err
+	}
+	toCoords, err := c.coordinates(to)
+	if err != nil {
+		return 0, err
+	}
+	return fromCoords.DistanceTo(toCoords), nil
+}
+
+// Nearest returns up to n locations closest to the location with the given
+// code, nearest first, excluding the location itself.
+func (c *Catalogue) Nearest(code string, n int) ([]Location, error) {
+	coords, err := c.coordinates(code)
+	if err != nil {
+		return nil, err
+	}
+	nearest := c.nearest(coords, code)
+	return nearest[:max(min(n, len(nearest)), 0)], nil
+}
+
+// NearestTo returns up to n locations closest to coords, nearest first.
+func (c *Catalogue) NearestTo(coords Coordinates, n int) []Location {
+	nearest := c.nearest(coords, "")
+	return nearest[:max(min(n, len(nearest)), 0)]
+}
+
+// ByContinent returns the locations of the catalogue grouped by continent and
+// sorted by code. Locations with an unknown continent are omitted.
+func (c *Catalogue) ByContinent() map[Continent][]Location {
+	continents := map[Continent][]Location{}
+	for _, location := range c.Locations() {
+		if location.Continent != "" {
+			continents[location.Continent] = append(continents[location.Continent], location)
+		}
+	}
+	return continents
+}
+
+func (c *Catalogue) coordinates(code string) (Coordinates, error) {
+	location, ok := c.locations[code]
+	if !ok {
+		return Coordinates{}, fmt.Errorf("location %s: %w", code, ErrNotFound)
+	}
+	if location.Coordinates == nil {
+		return Coordinates{}, fmt.Errorf("location %s has unknown coordinates", code)
+	}
+	return *location.Coordinates, nil
+}
+
+// nearest returns the locations with coordinates other than exclude, sorted
+// by distance to coords and then by code.
+func (c *Catalogue) nearest(coords Coordinates, exclude string) []Location {
+	type candidate struct {
+		location Location
+		distance float64
+	}
+	candidates := make([]candidate, 0, len(c.locations))
+	for code, location := range c.locations {
+		if code == exclude || location.Coordinates == nil {
+			continue
+		}
+		candidates = append(candidates, candidate{location, coords.DistanceTo(*location.Coordinates)})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].location.Code < candidates[j].location.Code
+	})
+
+	locations := make([]Location, len(candidates))
+	for i, candidate := range candidates {
+		locations[i] = candidate.location.clone()
+	}
+	return locations
+}
@@ -5,7 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -13,13 +13,32 @@ import (
 	"runtime/debug"
 )
 
-// Collection of all turso clients
+// Collection of all turso clients.
+//
+// Org must not be changed once the client is in use. Use ForOrg or
+// WithOrganization to operate on other organizations.
 type Client struct {
-	baseUrl    string
-	token      string
-	Org        string
-	version    string
-	httpClient *http.Client
+	baseUrl     string
+	tokenSource TokenSource
+	Org         string
+	version     string
+	httpClient  *http.Client
+
+	retryPolicy RetryPolicy
+	limiter     *rateLimiter
+	middleware  []Middleware
+	transport   Doer
+	logger      *slog.Logger
+	logLevels   LogLevels
+	tracer      Tracer
+	metrics     MetricsRecorder
+	secrets     *secrets
+
+	maxResponseSize    int64
+	regionDiscoveryURL string
+
+	// optionErr is set by options New rejects.
+	optionErr error
 
 	// Single instance to be reused by all clients
 	base *client
@@ -51,7 +70,10 @@ type ClientOption interface {
 const BaseURL = "https://api.turso.tech"
 
 func New(token string, org string, options ...ClientOption) (*Client, error) {
-	c := &Client{baseUrl: BaseURL, token: token, Org: org, httpClient: http.DefaultClient, version: getVersion()}
+	c := &Client{baseUrl: BaseURL, Org: org, httpClient: http.DefaultClient, version: getVersion(), logLevels: defaultLogLevels, secrets: &secrets{}, maxResponseSize: DefaultMaxResponseSize, regionDiscoveryURL: DefaultRegionDiscoveryURL}
+	if token != "" {
+		c.tokenSource = StaticTokenSource(token)
+	}
 
 	for _, option := range options {
 		option.apply(c)
@@ -60,7 +82,12 @@ func New(token string, org string, options ...ClientOption) (*Client, error) {
 	if err := c.validate(); err != nil {
 		return nil, err
 	}
+	c.transport = c.buildTransport()
+	c.initClients()
+	return c, nil
+}
 
+func (c *Client) initClients() {
 	c.base = &client{c}
 	c.Instances = (*InstancesClient)(c.base)
 	c.Databases = (*DatabasesClient)(c.base)
@@ -75,7 +102,41 @@ func New(token string, org string, options ...ClientOption) (*Client, error) {
 	c.Billing = (*BillingClient)(c.base)
 	c.Groups = (*GroupsClient)(c.base)
 	c.Invoices = (*InvoicesClient)(c.base)
-	return c, nil
+}
+
+// ForOrg returns a view of the client scoped to the organization slug. The
+// view shares the HTTP client, credentials, middleware, rate limits and every
+// other option with c, but has its own sub-clients, so views of different
+// organizations can be used concurrently. Creating a view is cheap.
+func (c *Client) ForOrg(slug string) *Client {
+	view := *c
+	view.Org = slug
+	view.initClients()
+	return &view
+}
+
+type organizationKey struct{}
+
+// WithOrganization returns a copy of ctx that makes any sub-client method
+// called with it operate on the organization slug instead of the client's Org.
+func WithOrganization(ctx context.Context, slug string) context.Context {
+	return context.WithValue(ctx, organizationKey{}, slug)
+}
+
+// org returns the organization a request made with ctx operates on.
+func (c *Client) org(ctx context.Context) string {
+	if slug, ok := ctx.Value(organizationKey{}).(string); ok {
+		return slug
+	}
+	return c.Org
+}
+
+// orgPrefix returns the path prefix of organization scoped endpoints.
+func (c *Client) orgPrefix(ctx context.Context) string {
+	if org := c.org(ctx); org != "" {
+		return "/v1/organizations/" + org
+	}
+	return "/v1"
 }
 
 var (
@@ -85,11 +146,15 @@ var (
 )
 
 func (c *Client) validate() error {
+	if c.optionErr != nil {
+		return c.optionErr
+	}
+
 	if c.baseUrl == "" {
 		return ErrMissingBaseURL
 	}
 
-	if c.token == "" {
+	if c.tokenSource == nil {
 		return ErrMissingAPIToken
 	}
 
@@ -141,11 +206,11 @@ func (o *withBaseUrl) apply(client *Client) {
 }
 
 func (c *Client) NewRequest(ctx context.Context, method, urlPath string, body io.Reader) (*http.Request, error) {
-	reqURL, err := url.Parse(c.baseUrl)
+	baseURL, err := url.Parse(c.baseUrl)
 	if err != nil {
 		return nil, err
 	}
-	reqURL, err = reqURL.Parse(urlPath)
+	reqURL, err := baseURL.Parse(urlPath)
 	if err != nil {
 		return nil, err
 	}
@@ -153,8 +218,17 @@ func (c *Client) NewRequest(ctx context.Context, method, urlPath string, body io
 	if err != nil {
 		return nil, err
 	}
-	if c.token != "" {
-		req.Header.Add("Authorization", fmt.Sprint("Bearer ", c.token))
+	// The API token is only sent to the Platform API, never to other hosts
+	// such as the region discovery endpoint.
+	if reqURL.Host == baseURL.Host {
+		token, err := c.tokenSource.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get API token: %w", err)
+		}
+		if token != "" {
+			c.secrets.add(token)
+			req.Header.Add("Authorization", fmt.Sprint("Bearer ", token))
+		}
 	}
 	req.Header.Add("User-Agent", fmt.Sprintf("turso-go/%s (%s/%s)", c.version, runtime.GOOS, runtime.GOARCH))
 	if body != nil {
@@ -163,16 +237,14 @@ func (c *Client) NewRequest(ctx context.Context, method, urlPath string, body io
 	return req, nil
 }
 
+// Do sends a request to the Platform API, retrying it according to the
+// client's RetryPolicy.
 func (c *Client) Do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
-	req, err := c.NewRequest(ctx, method, path, body)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.httpClient.Do(req)
+	reqBody, err := c.newRequestBody(method, body)
 	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+	return c.do(ctx, method, path, reqBody)
 }
 
 func (c *Client) Get(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
@@ -195,40 +267,9 @@ func (c *Client) Delete(ctx context.Context, path string, body io.Reader) (*http
 	return c.Do(ctx, "DELETE", path, body)
 }
 
+// Upload sends fileData as a multipart form file. When retries are enabled the
+// file is rewound and streamed again for every attempt. Use UploadReader to
+// upload other content.
 func (c *Client) Upload(ctx context.Context, path string, fileData *os.File) (*http.Response, error) {
-	body, bodyWriter := io.Pipe()
-	writer := multipart.NewWriter(bodyWriter)
-	go func() {
-		formFile, err := writer.CreateFormFile("file", fileData.Name())
-		if err != nil {
-			bodyWriter.CloseWithError(err)
-			return
-		}
-		if _, err := io.Copy(formFile, fileData); err != nil {
-			bodyWriter.CloseWithError(err)
-			return
-		}
-		bodyWriter.CloseWithError(writer.Close())
-	}()
-	req, err := c.NewRequest(ctx, "POST", path, body)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
-}
-
-func (c *Client) isNotMemberErr(status int) bool {
-	if status == http.StatusForbidden && c.Org != "" {
-		return true
-	}
-	return false
-}
-
-func (c *Client) notMemberErr() error {
-	return fmt.Errorf("not a member of organization %s", c.Org)
+	return c.UploadReader(ctx, path, Upload{Reader: fileData, Name: fileData.Name()})
 }
@@ -2,6 +2,9 @@ package turso_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/alehechka/turso-go"
@@ -54,3 +57,42 @@ func Test_Request_T(t *testing.T) {
 		t.Fatal("expected response to be nil")
 	}
 }
+
+func Test_ForOrg_ScopesRequests(t *testing.T) {
+	var paths sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths.Store(r.URL.Path, true)
+		w.Write([]byte(`{"databases":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for _, org := range []string{"first", "second"} {
+		wg.Add(1)
+		go func(view *turso.Client) {
+			defer wg.Done()
+			if _, err := view.Databases.List(context.TODO()); err != nil {
+				t.Error(err)
+			}
+		}(client.ForOrg(org))
+	}
+	wg.Wait()
+
+	if _, err := client.Databases.List(turso.WithOrganization(context.TODO(), "third")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"/v1/organizations/first/databases", "/v1/organizations/second/databases", "/v1/organizations/third/databases"} {
+		if _, ok := paths.Load(path); !ok {
+			t.Fatalf("expected a request to %s", path)
+		}
+	}
+	if client.Org != "my-org" {
+		t.Fatalf("expected the original client to keep its organization, got %s", client.Org)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
 )
@@ -19,19 +21,47 @@ type Database struct {
 	Version       string
 	Group         string
 	Sleeping      bool
+
+	Type             string `json:"type"`
+	Schema           string `json:"schema"`
+	IsSchema         bool   `json:"is_schema"`
+	AllowAttach      bool   `json:"allow_attach"`
+	BlockReads       bool   `json:"block_reads"`
+	BlockWrites      bool   `json:"block_writes"`
+	DeleteProtection bool   `json:"delete_protection"`
+	Archived         bool   `json:"archived"`
+
+	// Instances is only set by GetWithInstances.
+	Instances []Instance `json:"instances,omitempty"`
+
+	// Extra holds fields returned by the API that are not modeled above.
+	Extra Extra `json:"-"`
+}
+
+func (d *Database) UnmarshalJSON(data []byte) error {
+	type database Database
+	extra, err := unmarshalExtra(data, (*database)(d))
+	d.Extra = extra
+	return err
+}
+
+func (d Database) MarshalJSON() ([]byte, error) {
+	type database Database
+	return marshalExtra(database(d), d.Extra)
 }
 
 type DatabasesClient client
 
 func (c *DatabasesClient) List(ctx context.Context) ([]Database, error) {
-	res, err := c.client.Get(ctx, c.URL(""), nil)
+	ctx = WithOperation(ctx, "databases.list")
+	res, err := c.client.Get(ctx, c.url(ctx, ""), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get database listing: %s", err)
+		return nil, fmt.Errorf("failed to get database listing: %w", err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return nil, c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return nil, c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -46,19 +76,20 @@ func (c *DatabasesClient) List(ctx context.Context) ([]Database, error) {
 }
 
 func (c *DatabasesClient) Delete(ctx context.Context, database string) error {
-	url := c.URL("/" + database)
+	ctx = WithOperation(ctx, "databases.delete")
+	url := c.url(ctx, "/"+database)
 	res, err := c.client.Delete(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete database: %s", err)
+		return fmt.Errorf("failed to delete database: %w", err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("database %s not found", database)
+		return fmt.Errorf("failed to delete database %s: %w", database, parseResponseError(res))
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -78,6 +109,56 @@ type DBSeed struct {
 	Name      string     `json:"value,omitempty"`
 	URL       string     `json:"url,omitempty"`
 	Timestamp *time.Time `json:"timestamp,omitempty"`
+
+	// upload is sent once the database is created by CreateWithOptions.
+	upload *Upload
+}
+
+// SeedFromDatabase seeds a new database with a copy of the database with the
+// given name, as it was at the given time or as it is now when at is zero.
+func SeedFromDatabase(name string, at time.Time) *DBSeed {
+	seed := &DBSeed{Type: "database", Name: name}
+	if !at.IsZero() {
+		seed.Timestamp = &at
+	}
+	return seed
+}
+
+// SeedFromDumpURL seeds a new database from the SQL dump at url, e.g. as
+// returned by UploadDump.
+func SeedFromDumpURL(url string) *DBSeed {
+	return &DBSeed{Type: "dump", URL: url}
+}
+
+// SeedFromUpload seeds a new database with the SQLite database read from
+// upload. CreateWithOptions sends it with SeedReader once the database is
+// created; with Create it must be sent with Seed or SeedReader.
+func SeedFromUpload(upload Upload) *DBSeed {
+	return &DBSeed{Type: "database_upload", upload: &upload}
+}
+
+func (s *DBSeed) validate() error {
+	switch s.Type {
+	case "database":
+		if s.Name == "" {
+			return fmt.Errorf("%w: seed database name is required", ErrInvalidOptions)
+		}
+		return nil
+	case "dump":
+		if s.URL == "" {
+			return fmt.Errorf("%w: seed dump url is required", ErrInvalidOptions)
+		}
+	case "database_upload":
+		if s.upload != nil && s.upload.Reader == nil {
+			return fmt.Errorf("%w: seed upload reader is required", ErrInvalidOptions)
+		}
+	default:
+		return fmt.Errorf("%w: invalid seed type '%s'", ErrInvalidOptions, s.Type)
+	}
+	if s.Timestamp != nil {
+		return fmt.Errorf("%w: seed timestamp requires a database seed", ErrInvalidOptions)
+	}
+	return nil
 }
 
 type CreateDatabaseBody struct {
@@ -89,28 +170,42 @@ type CreateDatabaseBody struct {
 	Seed       *DBSeed `json:"seed,omitempty"`
 	Schema     string  `json:"schema,omitempty"`
 	IsSchema   bool    `json:"is_schema,omitempty"`
+	SizeLimit  string  `json:"size_limit,omitempty"`
 }
 
 func (c *DatabasesClient) Create(ctx context.Context, name, location, image, extensions, group string, schema string, isSchema bool, seed *DBSeed) (*CreateDatabaseResponse, error) {
-	params := CreateDatabaseBody{name, location, image, extensions, group, seed, schema, isSchema}
+	ctx = WithOperation(ctx, "databases.create")
+	return c.create(ctx, CreateDatabaseBody{
+		Name:       name,
+		Location:   location,
+		Image:      image,
+		Extensions: extensions,
+		Group:      group,
+		Seed:       seed,
+		Schema:     schema,
+		IsSchema:   isSchema,
+	})
+}
 
+func (c *DatabasesClient) create(ctx context.Context, params CreateDatabaseBody) (*CreateDatabaseResponse, error) {
+	name := params.Name
 	body, err := marshal(params)
 	if err != nil {
 		return nil, fmt.Errorf("could not serialize request body: %w", err)
 	}
 
-	res, err := c.client.Post(ctx, c.URL(""), body)
+	res, err := c.client.Post(ctx, c.url(ctx, ""), body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create database: %s", err)
+		return nil, fmt.Errorf("failed to create database: %w", err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return nil, c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return nil, c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode == http.StatusUnprocessableEntity {
-		return nil, fmt.Errorf("database name '%s' is not available", name)
+		return nil, fmt.Errorf("database name '%s' is not available: %w", name, parseResponseError(res))
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -125,20 +220,188 @@ func (c *DatabasesClient) Create(ctx context.Context, name, location, image, ext
 	return data, nil
 }
 
+// CreateDatabaseOptions configures a database created with CreateWithOptions.
+type CreateDatabaseOptions struct {
+	// Name of the database, required.
+	Name string
+	// Group the database is created in.
+	Group string
+	// Location of the database, for organizations without groups.
+	Location string
+	// Image and Extensions select the server version and extensions, for
+	// organizations without groups.
+	Image      string
+	Extensions string
+	// Seed is the initial content of the database, see SeedFromDatabase,
+	// SeedFromDumpURL and SeedFromUpload.
+	Seed *DBSeed
+	// Schema is the name of the schema database whose schema the database
+	// uses.
+	Schema string
+	// IsSchema creates a schema database, whose schema is shared by the
+	// databases created with it as Schema.
+	IsSchema bool
+	// SizeLimit caps the size of the database, e.g. "256mb".
+	SizeLimit string
+	// DeleteProtection prevents the database from being deleted.
+	DeleteProtection bool
+	// BlockReads and BlockWrites reject reads and writes to the database.
+	BlockReads  bool
+	BlockWrites bool
+}
+
+func (o CreateDatabaseOptions) validate() error {
+	if o.Name == "" {
+		return fmt.Errorf("%w: database name is required", ErrInvalidOptions)
+	}
+	if o.IsSchema && o.Schema != "" {
+		return fmt.Errorf("%w: a schema database cannot use schema '%s'", ErrInvalidOptions, o.Schema)
+	}
+	if o.Seed != nil {
+		return o.Seed.validate()
+	}
+	return nil
+}
+
+// CreatedDatabase is a database created with CreateWithOptions.
+type CreatedDatabase struct {
+	Database Database
+	Username string
+	// URL is the libsql:// URL to connect to the database.
+	URL string
+}
+
+// CreateWithOptions creates a database, uploads its seed when it was created
+// with SeedFromUpload, applies its configuration and returns it. Invalid
+// combinations of options are rejected with ErrInvalidOptions before any
+// request is sent. When a step fails after the database was created, the
+// database is deleted on a best effort basis and the error of the step is
+// returned.
+func (c *DatabasesClient) CreateWithOptions(ctx context.Context, opts CreateDatabaseOptions) (*CreatedDatabase, error) {
+	ctx = WithOperation(ctx, "databases.create")
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	created, err := c.create(ctx, CreateDatabaseBody{
+		Name:       opts.Name,
+		Location:   opts.Location,
+		Image:      opts.Image,
+		Extensions: opts.Extensions,
+		Group:      opts.Group,
+		Seed:       opts.Seed,
+		Schema:     opts.Schema,
+		IsSchema:   opts.IsSchema,
+		SizeLimit:  opts.SizeLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Seed != nil && opts.Seed.upload != nil {
+		if err := c.SeedReader(ctx, opts.Name, *opts.Seed.upload); err != nil {
+			return nil, c.deleteAfterFailure(ctx, opts.Name, err)
+		}
+	}
+
+	database, err := c.Get(ctx, opts.Name)
+	if err != nil {
+		return nil, c.deleteAfterFailure(ctx, opts.Name, err)
+	}
+
+	// Delete protection is applied last, so the database can still be
+	// deleted when a previous step fails.
+	if opts.DeleteProtection || opts.BlockReads || opts.BlockWrites {
+		config, err := c.GetConfig(ctx, opts.Name)
+		if err != nil {
+			return nil, c.deleteAfterFailure(ctx, opts.Name, err)
+		}
+		config.DeleteProtection, config.BlockReads, config.BlockWrites = &opts.DeleteProtection, &opts.BlockReads, &opts.BlockWrites
+		if err := c.UpdateConfig(ctx, opts.Name, config); err != nil {
+			return nil, c.deleteAfterFailure(ctx, opts.Name, err)
+		}
+		database.DeleteProtection, database.BlockReads, database.BlockWrites = opts.DeleteProtection, opts.BlockReads, opts.BlockWrites
+	}
+
+	return &CreatedDatabase{
+		Database: database,
+		Username: created.Username,
+		URL:      "libsql://" + database.Hostname,
+	}, nil
+}
+
+// deleteAfterFailure deletes a database left behind by an operation that
+// failed with err, even when ctx is canceled. err is returned, joined with
+// the error of the deletion if it fails too.
+func (c *DatabasesClient) deleteAfterFailure(ctx context.Context, name string, err error) error {
+	if deleteErr := c.Delete(context.WithoutCancel(ctx), name); deleteErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to delete database %s: %w", name, deleteErr))
+	}
+	return err
+}
+
+// Get returns the database with the given name. The returned error matches
+// ErrNotFound when the database does not exist. Use GetWithInstances to list
+// its instances too.
+func (c *DatabasesClient) Get(ctx context.Context, name string) (Database, error) {
+	ctx = WithOperation(ctx, "databases.get")
+	res, err := c.client.Get(ctx, c.url(ctx, "/"+name), nil)
+	if err != nil {
+		return Database{}, fmt.Errorf("failed to get database: %w", err)
+	}
+	defer res.Body.Close()
+
+	if c.client.isNotMemberErr(res) {
+		return Database{}, c.client.notMemberErr(res)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return Database{}, fmt.Errorf("failed to get database %s: %w", name, parseResponseError(res))
+	}
+
+	resp, err := unmarshal[struct{ Database Database }](res)
+	if err != nil {
+		return Database{}, err
+	}
+	return resp.Database, nil
+}
+
+// GetWithInstances returns the database with the given name like Get, with
+// its instances. When the database is found but its instances cannot be
+// listed, the database is returned along with the error.
+func (c *DatabasesClient) GetWithInstances(ctx context.Context, name string) (Database, error) {
+	database, err := c.Get(ctx, name)
+	if err != nil {
+		return Database{}, err
+	}
+	database.Instances, err = c.client.Instances.List(ctx, name)
+	if err != nil {
+		return database, fmt.Errorf("failed to list instances of database %s: %w", name, err)
+	}
+	return database, nil
+}
+
 func (c *DatabasesClient) Seed(ctx context.Context, name string, dbFile *os.File) error {
-	url := c.URL(fmt.Sprintf("/%s/seed", name))
-	res, err := c.client.Upload(ctx, url, dbFile)
+	return c.SeedReader(ctx, name, Upload{Reader: dbFile, Name: dbFile.Name()})
+}
+
+// SeedReader seeds the database with the given name from upload, see Upload
+// for progress reporting, compression and retries.
+func (c *DatabasesClient) SeedReader(ctx context.Context, name string, upload Upload) error {
+	ctx = WithOperation(ctx, "databases.seed")
+	url := c.url(ctx, fmt.Sprintf("/%s/seed", name))
+	res, err := c.client.UploadReader(ctx, url, upload)
 	if err != nil {
 		return fmt.Errorf("failed to create database: %w", err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode == http.StatusUnprocessableEntity {
-		return fmt.Errorf("database name '%s' is not available", name)
+		return fmt.Errorf("database name '%s' is not available: %w", name, parseResponseError(res))
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -148,15 +411,22 @@ func (c *DatabasesClient) Seed(ctx context.Context, name string, dbFile *os.File
 }
 
 func (c *DatabasesClient) UploadDump(ctx context.Context, dbFile *os.File) (string, error) {
-	url := c.URL("/dumps")
-	res, err := c.client.Upload(ctx, url, dbFile)
+	return c.UploadDumpReader(ctx, Upload{Reader: dbFile, Name: dbFile.Name()})
+}
+
+// UploadDumpReader uploads a SQL dump from upload and returns its URL, to be
+// used as the seed of a new database.
+func (c *DatabasesClient) UploadDumpReader(ctx context.Context, upload Upload) (string, error) {
+	ctx = WithOperation(ctx, "databases.upload_dump")
+	url := c.url(ctx, "/dumps")
+	res, err := c.client.UploadReader(ctx, url, upload)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload the dump file: %w", err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return "", c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return "", c.client.notMemberErr(res)
 	}
 	if res.StatusCode != http.StatusOK {
 		return "", parseResponseError(res)
@@ -176,11 +446,12 @@ type DatabaseTokenRequest struct {
 }
 
 func (c *DatabasesClient) Token(ctx context.Context, database string, expiration string, readOnly bool, permissions *PermissionsClaim) (string, error) {
+	ctx = WithOperation(ctx, "databases.token")
 	authorization := ""
 	if readOnly {
 		authorization = "&authorization=read-only"
 	}
-	url := c.URL(fmt.Sprintf("/%s/auth/tokens?expiration=%s%s", database, expiration, authorization))
+	url := c.url(ctx, fmt.Sprintf("/%s/auth/tokens?expiration=%s%s", database, expiration, authorization))
 
 	req := DatabaseTokenRequest{permissions}
 	body, err := marshal(req)
@@ -194,8 +465,8 @@ func (c *DatabasesClient) Token(ctx context.Context, database string, expiration
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return "", c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return "", c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -211,15 +482,16 @@ func (c *DatabasesClient) Token(ctx context.Context, database string, expiration
 }
 
 func (c *DatabasesClient) Rotate(ctx context.Context, database string) error {
-	url := c.URL(fmt.Sprintf("/%s/auth/rotate", database))
+	ctx = WithOperation(ctx, "databases.rotate")
+	url := c.url(ctx, fmt.Sprintf("/%s/auth/rotate", database))
 	res, err := c.client.Post(ctx, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to rotate database keys: %w", err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -230,7 +502,8 @@ func (c *DatabasesClient) Rotate(ctx context.Context, database string) error {
 }
 
 func (c *DatabasesClient) Update(ctx context.Context, database string, group bool) error {
-	url := c.URL(fmt.Sprintf("/%s/update", database))
+	ctx = WithOperation(ctx, "databases.update")
+	url := c.url(ctx, fmt.Sprintf("/%s/update", database))
 	if group {
 		url += "?group=true"
 	}
@@ -240,8 +513,8 @@ func (c *DatabasesClient) Update(ctx context.Context, database string, group boo
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -251,24 +524,17 @@ func (c *DatabasesClient) Update(ctx context.Context, database string, group boo
 	return nil
 }
 
-type Stats struct {
-	TopQueries []struct {
-		Query       string `json:"query"`
-		RowsRead    int    `json:"rows_read"`
-		RowsWritten int    `json:"rows_written"`
-	} `json:"top_queries,omitempty"`
-}
-
 func (c *DatabasesClient) Stats(ctx context.Context, database string) (Stats, error) {
-	url := c.URL(fmt.Sprintf("/%s/stats", database))
+	ctx = WithOperation(ctx, "databases.stats")
+	url := c.url(ctx, fmt.Sprintf("/%s/stats", database))
 	res, err := c.client.Get(ctx, url, nil)
 	if err != nil {
-		return Stats{}, fmt.Errorf("failed to update database: %w", err)
+		return Stats{}, fmt.Errorf("failed to get stats for database: %w", err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return Stats{}, c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return Stats{}, c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -283,7 +549,8 @@ type Body struct {
 }
 
 func (c *DatabasesClient) Transfer(ctx context.Context, database, org string) error {
-	url := c.URL(fmt.Sprintf("/%s/transfer", database))
+	ctx = WithOperation(ctx, "databases.transfer")
+	url := c.url(ctx, fmt.Sprintf("/%s/transfer", database))
 	body, err := json.Marshal(Body{Org: org})
 	bodyReader := bytes.NewReader(body)
 	if err != nil {
@@ -291,7 +558,7 @@ func (c *DatabasesClient) Transfer(ctx context.Context, database, org string) er
 	}
 	res, err := c.client.Post(ctx, url, bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to transfer database")
+		return fmt.Errorf("failed to transfer database: %w", err)
 	}
 	defer res.Body.Close()
 
@@ -303,15 +570,16 @@ func (c *DatabasesClient) Transfer(ctx context.Context, database, org string) er
 }
 
 func (c *DatabasesClient) Wakeup(ctx context.Context, database string) error {
-	url := c.URL(fmt.Sprintf("/%s/wakeup", database))
+	ctx = WithOperation(ctx, "databases.wakeup")
+	url := c.url(ctx, fmt.Sprintf("/%s/wakeup", database))
 	res, err := c.client.Post(ctx, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to wakeup database: %w", err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -344,9 +612,30 @@ type DbUsageResponse struct {
 }
 
 func (c *DatabasesClient) Usage(ctx context.Context, database string) (DbUsage, error) {
-	url := c.URL(fmt.Sprintf("/%s/usage", database))
+	return c.UsageBetween(ctx, database, time.Time{}, time.Time{})
+}
 
-	res, err := c.client.Get(ctx, url, nil)
+// UsageBetween returns the usage of a database between from and to. A zero
+// from or to leaves the corresponding end of the range to the API, which
+// defaults to the current billing period.
+func (c *DatabasesClient) UsageBetween(ctx context.Context, database string, from, to time.Time) (DbUsage, error) {
+	ctx = WithOperation(ctx, "databases.usage")
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return DbUsage{}, fmt.Errorf("%w: usage range ends before it starts", ErrInvalidOptions)
+	}
+	path := c.url(ctx, fmt.Sprintf("/%s/usage", database))
+	query := url.Values{}
+	if !from.IsZero() {
+		query.Set("from", from.UTC().Format(time.RFC3339))
+	}
+	if !to.IsZero() {
+		query.Set("to", to.UTC().Format(time.RFC3339))
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	res, err := c.client.Get(ctx, path, nil)
 	if err != nil {
 		return DbUsage{}, fmt.Errorf("failed to get database usage: %w", err)
 	}
@@ -364,39 +653,45 @@ func (c *DatabasesClient) Usage(ctx context.Context, database string) (DbUsage,
 }
 
 func (c *DatabasesClient) URL(suffix string) string {
-	prefix := "/v1"
-	if c.client.Org != "" {
-		prefix = "/v1/organizations/" + c.client.Org
-	}
-	return prefix + "/databases" + suffix
+	return c.url(context.Background(), suffix)
+}
+
+func (c *DatabasesClient) url(ctx context.Context, suffix string) string {
+	return c.client.orgPrefix(ctx) + "/databases" + suffix
 }
 
 type DatabaseConfig struct {
 	AllowAttach bool `json:"allow_attach"`
+	// The fields below are left unchanged by UpdateConfig when empty.
+	SizeLimit        string `json:"size_limit,omitempty"`
+	BlockReads       *bool  `json:"block_reads,omitempty"`
+	BlockWrites      *bool  `json:"block_writes,omitempty"`
+	DeleteProtection *bool  `json:"delete_protection,omitempty"`
 }
 
 func (c *DatabasesClient) GetConfig(ctx context.Context, database string) (DatabaseConfig, error) {
-	url := c.URL(fmt.Sprintf("/%s/configuration", database))
+	ctx = WithOperation(ctx, "databases.get_config")
+	url := c.url(ctx, fmt.Sprintf("/%s/configuration", database))
 	res, err := c.client.Get(ctx, url, nil)
 	if err != nil {
 		return DatabaseConfig{}, fmt.Errorf("failed to get database: %w", err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return DatabaseConfig{}, c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return DatabaseConfig{}, c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
-		err = parseResponseError(res)
-		return DatabaseConfig{}, fmt.Errorf("failed to get config for database: %d %s", res.StatusCode, err)
+		return DatabaseConfig{}, fmt.Errorf("failed to get config for database: %w", parseResponseError(res))
 	}
 
 	return unmarshal[DatabaseConfig](res)
 }
 
 func (c *DatabasesClient) UpdateConfig(ctx context.Context, database string, config DatabaseConfig) error {
-	url := c.URL(fmt.Sprintf("/%s/configuration", database))
+	ctx = WithOperation(ctx, "databases.update_config")
+	url := c.url(ctx, fmt.Sprintf("/%s/configuration", database))
 	body, err := marshal(config)
 	if err != nil {
 		return fmt.Errorf("could not serialize request body: %w", err)
@@ -407,13 +702,12 @@ func (c *DatabasesClient) UpdateConfig(ctx context.Context, database string, con
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
-		err = parseResponseError(res)
-		return fmt.Errorf("failed to update config for database: %d %s", res.StatusCode, err)
+		return fmt.Errorf("failed to update config for database: %w", parseResponseError(res))
 	}
 
 	return nil
@@ -0,0 +1,160 @@
+package turso_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/tursotest"
+)
+
+func Test_Databases_CreateWithOptions(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+	ctx := context.TODO()
+
+	if err := client.Groups.Create(ctx, "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := client.Databases.CreateWithOptions(ctx, turso.CreateDatabaseOptions{
+		Name:             "my-db",
+		Group:            "default",
+		Seed:             turso.SeedFromUpload(turso.Upload{Reader: bytes.NewReader(sqliteFile(4096, 2, false))}),
+		SizeLimit:        "256mb",
+		DeleteProtection: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Database.Name != "my-db" || created.Database.Group != "default" || created.Database.PrimaryRegion != "ams" {
+		t.Fatalf("expected the full database, got: %+v", created.Database)
+	}
+	if created.URL != "libsql://"+created.Database.Hostname {
+		t.Fatalf("unexpected url: %s", created.URL)
+	}
+
+	config, err := client.Databases.GetConfig(ctx, "my-db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.SizeLimit != "256mb" || config.DeleteProtection == nil || !*config.DeleteProtection {
+		t.Fatalf("expected the configuration to be applied, got: %+v", config)
+	}
+	if err := client.Databases.Delete(ctx, "my-db"); err == nil {
+		t.Fatal("expected a delete protected database not to be deleted")
+	}
+
+	if _, err := client.Databases.CreateWithOptions(ctx, turso.CreateDatabaseOptions{
+		Name:  "my-copy",
+		Group: "default",
+		Seed:  turso.SeedFromDatabase("my-db", time.Now().Add(-time.Hour)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_Databases_CreateWithOptions_DeletesOnFailure(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+	ctx := context.TODO()
+
+	if err := client.Groups.Create(ctx, "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	server.InjectFailure(tursotest.Failure{Path: "/v1/organizations/*/databases/my-db/seed", Status: http.StatusInternalServerError, Times: 1})
+
+	_, err := client.Databases.CreateWithOptions(ctx, turso.CreateDatabaseOptions{
+		Name:             "my-db",
+		Group:            "default",
+		Seed:             turso.SeedFromUpload(turso.Upload{Reader: bytes.NewReader(sqliteFile(4096, 2, false))}),
+		DeleteProtection: true,
+	})
+	var apiErr *turso.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected the seed error, got: %v", err)
+	}
+	if _, err := client.Databases.Get(ctx, "my-db"); !errors.Is(err, turso.ErrNotFound) {
+		t.Fatalf("expected the database to be deleted, got: %v", err)
+	}
+}
+
+func Test_Databases_CreateWithOptions_Invalid(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+
+	tests := map[string]turso.CreateDatabaseOptions{
+		"missing name":       {Group: "default"},
+		"schema with schema": {Name: "my-db", IsSchema: true, Schema: "parent"},
+		"missing dump url":   {Name: "my-db", Seed: turso.SeedFromDumpURL("")},
+		"missing database":   {Name: "my-db", Seed: turso.SeedFromDatabase("", time.Time{})},
+		"missing reader":     {Name: "my-db", Seed: turso.SeedFromUpload(turso.Upload{})},
+		"unknown seed":       {Name: "my-db", Seed: &turso.DBSeed{Type: "backup"}},
+	}
+
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := client.Databases.CreateWithOptions(context.TODO(), opts); !errors.Is(err, turso.ErrInvalidOptions) {
+				t.Fatalf("expected ErrInvalidOptions, got: %v", err)
+			}
+		})
+	}
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Fatalf("expected invalid options to be rejected locally, got %d requests", len(requests))
+	}
+}
+
+func Test_Databases_Get(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+	ctx := context.TODO()
+
+	if err := client.Groups.Create(ctx, "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Groups.AddLocation(ctx, "default", "fra"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.CreateWithOptions(ctx, turso.CreateDatabaseOptions{Name: "parent", Group: "default", IsSchema: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.CreateWithOptions(ctx, turso.CreateDatabaseOptions{Name: "my-db", Group: "default", Schema: "parent", BlockWrites: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := client.Databases.Get(ctx, "my-db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.ID == "" || db.Schema != "parent" || db.IsSchema || !db.BlockWrites || db.BlockReads || db.DeleteProtection {
+		t.Fatalf("unexpected database metadata: %+v", db)
+	}
+	if len(db.Instances) != 0 {
+		t.Fatalf("expected Get not to list instances, got: %+v", db.Instances)
+	}
+
+	server.InjectFailure(tursotest.Failure{Path: "/v1/organizations/*/databases/my-db/instances", Status: http.StatusInternalServerError, Times: 1})
+	db, err = client.Databases.GetWithInstances(ctx, "my-db")
+	if err == nil || db.Name != "my-db" {
+		t.Fatalf("expected the database along with the instances error, got %+v and %v", db, err)
+	}
+	db, err = client.Databases.GetWithInstances(ctx, "my-db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(db.Instances) != 2 || db.Instances[0].Region != "ams" || db.Instances[1].Region != "fra" {
+		t.Fatalf("expected the primary and replica instances, got: %+v", db.Instances)
+	}
+
+	if _, err := client.Databases.Get(ctx, "missing"); !errors.Is(err, turso.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package turso
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environment variables read by NewFromEnvironment.
+const (
+	EnvAPIToken = "TURSO_API_TOKEN"
+	EnvOrg      = "TURSO_ORG"
+)
+
+// CLISettings is the subset of the Turso CLI settings file read by
+// NewFromEnvironment. The CLI stores the credentials of the logged in user
+// under the "config" key.
+type CLISettings struct {
+	Config CLIConfig `json:"config"`
+}
+
+// CLIConfig holds the credentials stored by the Turso CLI. Organization is
+// empty when the personal organization of the user is selected.
+type CLIConfig struct {
+	Token        string `json:"token"`
+	Username     string `json:"username"`
+	Organization string `json:"organization"`
+}
+
+// DefaultSettingsPath returns the location of the Turso CLI settings file,
+// turso/settings.json in the user's configuration directory ($XDG_CONFIG_HOME
+// or ~/.config on Linux).
+func DefaultSettingsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "turso", "settings.json"), nil
+}
+
+// ErrEnvironmentOption is returned by New when it is given an option that
+// only applies to NewFromEnvironment, such as WithSettingsFile.
+var ErrEnvironmentOption = errors.New("option only applies to NewFromEnvironment")
+
+// environmentOption is implemented by options that only affect how
+// NewFromEnvironment resolves credentials. New rejects them.
+type environmentOption interface {
+	applyEnvironment(*environment)
+}
+
+type environment struct {
+	settingsPath string
+}
+
+type withSettingsFile struct {
+	path string
+}
+
+// WithSettingsFile reads the Turso CLI settings from path instead of
+// DefaultSettingsPath. It overrides the TURSO_API_TOKEN and TURSO_ORG
+// environment variables. It only applies to NewFromEnvironment, New returns
+// ErrEnvironmentOption.
+func WithSettingsFile(path string) ClientOption {
+	return &withSettingsFile{path: path}
+}
+
+func (o *withSettingsFile) apply(client *Client) {
+	client.optionErr = fmt.Errorf("%w: WithSettingsFile", ErrEnvironmentOption)
+}
+
+func (o *withSettingsFile) applyEnvironment(env *environment) {
+	env.settingsPath = o.path
+}
+
+// NewFromEnvironment creates a client with the token and organization
+// resolved as follows:
+//
+//  1. With WithSettingsFile, both are read from that settings file, which must
+//     exist. The environment variables are ignored.
+//  2. Otherwise, when TURSO_API_TOKEN is set, the token is taken from it and
+//     the organization from TURSO_ORG.
+//  3. Otherwise, the token is read from the Turso CLI settings file, see
+//     DefaultSettingsPath. The organization is taken from TURSO_ORG, so an
+//     organization can be picked for the user logged in with the CLI, or else
+//     from the settings file. A missing settings file is ignored.
+//
+// A settings file without an organization selects the personal organization
+// of its user. When no token is found, the error lists every source that was
+// consulted. A missing token is not an error when WithTokenSource is among
+// the options.
+func NewFromEnvironment(options ...ClientOption) (*Client, error) {
+	env := &environment{}
+	clientOptions := make([]ClientOption, 0, len(options))
+	for _, option := range options {
+		if option, ok := option.(environmentOption); ok {
+			option.applyEnvironment(env)
+			continue
+		}
+		clientOptions = append(clientOptions, option)
+	}
+
+	var token, org string
+	var tried []string
+	path, required := env.settingsPath, true
+	if path == "" {
+		token, org = os.Getenv(EnvAPIToken), os.Getenv(EnvOrg)
+		tried = append(tried, "environment variable "+EnvAPIToken)
+		if token == "" {
+			var err error
+			if path, err = DefaultSettingsPath(); err != nil {
+				return nil, fmt.Errorf("failed to locate Turso CLI settings: %w", err)
+			}
+			required = false
+		}
+	}
+
+	if token == "" {
+		settings, err := readSettings(path, required)
+		if err != nil {
+			return nil, err
+		}
+		tried = append(tried, "settings file "+path)
+		token = settings.Config.Token
+		if org == "" {
+			org = settings.Config.Organization
+		}
+		if org == "" {
+			org = settings.Config.Username
+		}
+	}
+
+	client, err := New(token, org, clientOptions...)
+	if errors.Is(err, ErrMissingAPIToken) {
+		return nil, fmt.Errorf("%w: tried %s", err, strings.Join(tried, ", "))
+	}
+	return client, err
+}
+
+// readSettings reads the Turso CLI settings file at path. A missing file is
+// treated as empty settings unless it is required.
+func readSettings(path string, required bool) (CLISettings, error) {
+	var settings CLISettings
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, fmt.Errorf("failed to read Turso CLI settings: %w", err)
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return settings, fmt.Errorf("failed to parse Turso CLI settings file %s: %w", path, err)
+	}
+	return settings, nil
+}
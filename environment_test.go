@@ -0,0 +1,122 @@
+package turso_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alehechka/turso-go"
+)
+
+func writeSettings(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func Test_NewFromEnvironment_Precedence(t *testing.T) {
+	path := filepath.Join("testdata", "cli-settings.json")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(turso.EnvAPIToken, "env-token")
+	t.Setenv(turso.EnvOrg, "env-org")
+
+	client, err := turso.NewFromEnvironment(turso.WithSettingsFile(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Org != "my-team" {
+		t.Fatalf("expected WithSettingsFile to override the environment, got %q", client.Org)
+	}
+
+	client, err = turso.NewFromEnvironment()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Org != "env-org" {
+		t.Fatalf("expected organization from environment, got %q", client.Org)
+	}
+}
+
+func Test_NewFromEnvironment_OrgForCLIToken(t *testing.T) {
+	config := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	t.Setenv(turso.EnvAPIToken, "")
+	t.Setenv(turso.EnvOrg, "env-org")
+
+	path, err := turso.DefaultSettingsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"config": {"token": "cli-token", "organization": "my-team"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := turso.NewFromEnvironment()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Org != "env-org" {
+		t.Fatalf("expected TURSO_ORG to select the organization of the CLI user, got %q", client.Org)
+	}
+
+	t.Setenv(turso.EnvOrg, "")
+	client, err = turso.NewFromEnvironment()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Org != "my-team" {
+		t.Fatalf("expected organization from settings file, got %q", client.Org)
+	}
+}
+
+func Test_NewFromEnvironment_PersonalOrganization(t *testing.T) {
+	path := writeSettings(t, `{"config": {"token": "cli-token", "username": "my-user"}}`)
+
+	t.Setenv(turso.EnvAPIToken, "")
+	t.Setenv(turso.EnvOrg, "")
+
+	client, err := turso.NewFromEnvironment(turso.WithSettingsFile(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Org != "my-user" {
+		t.Fatalf("expected the personal organization, got %q", client.Org)
+	}
+}
+
+func Test_NewFromEnvironment_ReportsSourcesTried(t *testing.T) {
+	config := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	t.Setenv(turso.EnvAPIToken, "")
+	t.Setenv(turso.EnvOrg, "")
+
+	path, err := turso.DefaultSettingsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = turso.NewFromEnvironment()
+	if !errors.Is(err, turso.ErrMissingAPIToken) {
+		t.Fatalf("expected missing token error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), turso.EnvAPIToken) || !strings.Contains(err.Error(), path) {
+		t.Fatalf("expected error to list the sources tried, got: %v", err)
+	}
+
+	if _, err := turso.NewFromEnvironment(turso.WithSettingsFile(filepath.Join(config, "missing.json"))); err == nil {
+		t.Fatal("expected a missing settings file set with WithSettingsFile to return an error")
+	}
+}
+
+func Test_New_RejectsEnvironmentOptions(t *testing.T) {
+	if _, err := turso.New("my-token", "my-org", turso.WithSettingsFile("settings.json")); !errors.Is(err, turso.ErrEnvironmentOption) {
+		t.Fatalf("expected ErrEnvironmentOption, got: %v", err)
+	}
+}
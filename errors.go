@@ -0,0 +1,174 @@
+package turso
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors that can be matched against any error returned by the
+// clients with errors.Is.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrForbidden       = errors.New("forbidden")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrPaymentRequired = errors.New("payment required")
+	ErrNotMember       = errors.New("not a member of organization")
+	ErrInvalidOptions  = errors.New("invalid options")
+)
+
+// RequestIDHeader is the response header carrying the request ID assigned by
+// the Platform API.
+const RequestIDHeader = "X-Request-Id"
+
+// maxErrorBodySize caps how much of a failed response body is retained.
+const maxErrorBodySize = 64 << 10
+
+// APIError is returned, possibly wrapped, whenever the Platform API responds
+// with an unexpected status code. Use errors.As to access it.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Status is the HTTP status line of the response, e.g. "404 Not Found".
+	Status string
+	// Code is the machine readable error code sent by the server, if any.
+	Code string
+	// Message is the human readable error message sent by the server, if any.
+	Message string
+	// Body is the raw response body, truncated to 64KiB.
+	Body []byte
+	// RequestID is the value of the RequestIDHeader response header.
+	RequestID string
+	// Method and Path identify the request that failed.
+	Method string
+	Path   string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("response failed with status %s", e.Status)
+}
+
+// Is reports whether the error matches one of the status based sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrPaymentRequired:
+		return e.StatusCode == http.StatusPaymentRequired
+	}
+	return false
+}
+
+// maxSnippetSize caps how much of a response body is quoted by a DecodeError.
+const maxSnippetSize = 256
+
+// DecodeError is returned, possibly wrapped, when a successful response body
+// cannot be decoded. Use errors.As to access it.
+type DecodeError struct {
+	// Operation is the logical operation name, e.g. "organizations.usage".
+	Operation  string
+	StatusCode int
+	// Snippet is the start of the response body, truncated to 256 bytes, with
+	// bearer credentials and JWTs redacted.
+	Snippet string
+	// Err is the underlying decoding or read error, e.g. ErrResponseTooLarge.
+	Err error
+
+	truncated bool
+}
+
+func (e *DecodeError) Error() string {
+	operation := e.Operation
+	if operation == "" {
+		operation = "unknown operation"
+	}
+	snippet := e.Snippet
+	if e.truncated {
+		snippet += "..."
+	}
+	return fmt.Sprintf("failed to decode %s response: %v (body: %q)", operation, e.Err, snippet)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func parseResponseError(res *http.Response) error {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		RequestID:  res.Header.Get(RequestIDHeader),
+	}
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.Path = res.Request.URL.Path
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	apiErr.Body = body
+
+	var payload struct {
+		Error   json.RawMessage `json:"error"`
+		Code    string          `json:"code"`
+		Message string          `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return apiErr
+	}
+	apiErr.Code = payload.Code
+	apiErr.Message = payload.Message
+
+	var message string
+	var detail struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	switch {
+	case len(payload.Error) == 0 || string(payload.Error) == "null":
+	case json.Unmarshal(payload.Error, &message) == nil:
+		apiErr.Message = message
+	case json.Unmarshal(payload.Error, &detail) == nil && detail.Message != "":
+		apiErr.Message = detail.Message
+		if detail.Code != "" {
+			apiErr.Code = detail.Code
+		}
+	default:
+		apiErr.Message = strings.TrimSpace(string(payload.Error))
+	}
+
+	return apiErr
+}
+
+func (c *Client) isNotMemberErr(res *http.Response) bool {
+	if res.StatusCode == http.StatusForbidden && c.org(responseContext(res)) != "" {
+		return true
+	}
+	return false
+}
+
+func (c *Client) notMemberErr(res *http.Response) error {
+	return fmt.Errorf("%w %s: %w", ErrNotMember, c.org(responseContext(res)), parseResponseError(res))
+}
+
+// responseContext returns the context of the request res answers. Responses
+// built by a Doer or middleware may have no request.
+func responseContext(res *http.Response) context.Context {
+	if res.Request == nil {
+		return context.Background()
+	}
+	return res.Request.Context()
+}
@@ -0,0 +1,92 @@
+package turso_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alehechka/turso-go"
+)
+
+func Test_APIError_MatchesSentinels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(turso.RequestIDHeader, "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"group not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Groups.Get(context.TODO(), "missing")
+	if !errors.Is(err, turso.ErrNotFound) {
+		t.Fatalf("expected error to match ErrNotFound, got: %v", err)
+	}
+
+	var apiErr *turso.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected error to be an APIError, got: %T", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status code %d, got %d", http.StatusNotFound, apiErr.StatusCode)
+	}
+	if apiErr.Message != "group not found" {
+		t.Fatalf("expected server message, got: %q", apiErr.Message)
+	}
+	if apiErr.RequestID != "req-123" {
+		t.Fatalf("expected request ID, got: %q", apiErr.RequestID)
+	}
+	if apiErr.Method != http.MethodGet || apiErr.Path != "/v1/organizations/my-org/groups/missing" {
+		t.Fatalf("unexpected request details: %s %s", apiErr.Method, apiErr.Path)
+	}
+}
+
+func Test_APIError_NotMember(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"code":"not_member","error":"forbidden"}`))
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.Databases.Delete(context.TODO(), "my-db")
+	if !errors.Is(err, turso.ErrNotMember) {
+		t.Fatalf("expected error to match ErrNotMember, got: %v", err)
+	}
+	if !errors.Is(err, turso.ErrForbidden) {
+		t.Fatalf("expected error to match ErrForbidden, got: %v", err)
+	}
+
+	var apiErr *turso.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "not_member" {
+		t.Fatalf("expected APIError with server code, got: %v", err)
+	}
+}
+
+func Test_Errors_WrapTransportErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	if _, err := client.Instances.List(ctx, "my-db"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if err := client.Instances.Wait(ctx, "my-db", "my-instance"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package turso
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Extra holds the fields of an API object that the SDK does not model yet,
+// keyed by their JSON name. They are kept when the object is encoded again
+// with encoding/json, e.g. to cache or forward it. They are not sent by
+// update methods, which only send the fields they take as arguments.
+type Extra map[string]json.RawMessage
+
+// Decode decodes the field named key into v. It reports false when the field
+// is absent.
+func (e Extra) Decode(key string, v any) (bool, error) {
+	raw, ok := e[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+// knownFields caches the lowercased JSON field names of model types.
+var knownFields sync.Map
+
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if names, ok := knownFields.Load(t); ok {
+		return names.(map[string]bool)
+	}
+	names := map[string]bool{}
+	addJSONFieldNames(t, names)
+	knownFields.Store(t, names)
+	return names
+}
+
+// addJSONFieldNames adds the names of the fields of t to names, including
+// the fields promoted from embedded structs like encoding/json does.
+func addJSONFieldNames(t reflect.Type, names map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		embedded := field.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		isStruct := embedded.Kind() == reflect.Struct
+		if field.Anonymous && isStruct && name == "" {
+			addJSONFieldNames(embedded, names)
+			continue
+		}
+		if !field.IsExported() && !(field.Anonymous && isStruct) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+}
+
+// unmarshalExtra decodes data into v, a pointer to a struct without an
+// UnmarshalJSON method, and returns the fields v does not declare. Like
+// encoding/json, field names are matched case-insensitively.
+func unmarshalExtra(data []byte, v any) (Extra, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+	var extra Extra
+	for key, value := range fields {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		if extra == nil {
+			extra = Extra{}
+		}
+		extra[key] = value
+	}
+	return extra, nil
+}
+
+// marshalExtra encodes v, a struct without a MarshalJSON method, merged with
+// extra. Fields declared by v take precedence.
+func marshalExtra(v any, extra Extra) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	for key, value := range extra {
+		fields[key] = value
+	}
+	var declared map[string]json.RawMessage
+	if err := json.Unmarshal(data, &declared); err != nil {
+		return nil, err
+	}
+	for key, value := range declared {
+		fields[key] = value
+	}
+	return json.Marshal(fields)
+}
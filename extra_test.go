@@ -0,0 +1,68 @@
+package turso_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alehechka/turso-go"
+)
+
+func Test_Extra_PreservesUnknownFields(t *testing.T) {
+	data := []byte(`{"Name":"my-db","DbId":"1234","group":"default","encryption":true,"parent_id":"5678","backup":{"enabled":true}}`)
+
+	var db turso.Database
+	if err := json.Unmarshal(data, &db); err != nil {
+		t.Fatal(err)
+	}
+	if db.Name != "my-db" || db.ID != "1234" || db.Group != "default" {
+		t.Fatalf("expected modeled fields to be decoded, got: %+v", db)
+	}
+	if len(db.Extra) != 3 {
+		t.Fatalf("expected 3 extra fields, got: %v", db.Extra)
+	}
+
+	var encryption bool
+	if ok, err := db.Extra.Decode("encryption", &encryption); !ok || err != nil || !encryption {
+		t.Fatalf("expected encryption to be decoded from extra fields, got %v %v", ok, err)
+	}
+
+	db.Group = "other"
+	encoded, err := json.Marshal(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["Group"] != "other" || fields["encryption"] != true || fields["backup"] == nil {
+		t.Fatalf("expected modeled and extra fields to be encoded, got: %s", encoded)
+	}
+}
+
+func Test_Extra_PreservesNestedUnknownFields(t *testing.T) {
+	data := []byte(`{"name":"scaler","quotas":{"storage":25769803776,"embeddedReplicas":true},"trial":false}`)
+
+	var plan turso.Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		t.Fatal(err)
+	}
+	if plan.Quotas.Storage != 24<<30 || plan.Quotas.Extra["embeddedReplicas"] == nil || plan.Extra["trial"] == nil {
+		t.Fatalf("expected quotas and extra fields to be decoded, got: %+v", plan)
+	}
+	if _, ok := plan.Extra["quotas"]; ok {
+		t.Fatalf("expected quotas not to be an extra field, got: %v", plan.Extra)
+	}
+
+	encoded, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded turso.Plan
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Quotas.Extra["embeddedReplicas"] == nil {
+		t.Fatalf("expected nested extra fields to be encoded, got: %s", encoded)
+	}
+}
@@ -0,0 +1,50 @@
+package turso
+
+import "sync"
+
+// FakeCall is a call recorded by a fake. Args holds the arguments of the call
+// except its context.
+type FakeCall struct {
+	Method string
+	Args   []any
+}
+
+// FakeCalls records the calls made to a fake. It is embedded by every
+// generated fake and is safe for concurrent use.
+type FakeCalls struct {
+	mu    sync.Mutex
+	calls []FakeCall
+}
+
+// Calls returns every call made so far, in order.
+func (f *FakeCalls) Calls() []FakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeCall(nil), f.calls...)
+}
+
+// CallsTo returns the calls made so far to method.
+func (f *FakeCalls) CallsTo(method string) []FakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []FakeCall
+	for _, call := range f.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset discards the recorded calls.
+func (f *FakeCalls) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+
+func (f *FakeCalls) record(method string, args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, FakeCall{Method: method, Args: args})
+}
@@ -9,6 +9,7 @@ import (
 type FeedbackClient client
 
 func (f *FeedbackClient) Submit(ctx context.Context, summary, feedback string) error {
+	ctx = WithOperation(ctx, "feedback.submit")
 	body := struct{ Summary, Feedback string }{summary, feedback}
 	reader, err := marshal(body)
 	if err != nil {
@@ -17,7 +18,7 @@ func (f *FeedbackClient) Submit(ctx context.Context, summary, feedback string) e
 
 	r, err := f.client.Post(ctx, "/v1/feedback", reader)
 	if err != nil {
-		return fmt.Errorf("failed to post feedback: %s", err)
+		return fmt.Errorf("failed to post feedback: %w", err)
 	}
 	defer r.Body.Close()
 
@@ -14,21 +14,37 @@ type Group struct {
 	Primary   string   `json:"primary"`
 	Archived  bool     `json:"archived"`
 	Version   string   `json:"version"`
+
+	// Extra holds fields returned by the API that are not modeled above.
+	Extra Extra `json:"-"`
+}
+
+func (g *Group) UnmarshalJSON(data []byte) error {
+	type group Group
+	extra, err := unmarshalExtra(data, (*group)(g))
+	g.Extra = extra
+	return err
+}
+
+func (g Group) MarshalJSON() ([]byte, error) {
+	type group Group
+	return marshalExtra(group(g), g.Extra)
 }
 
 func (g *GroupsClient) List(ctx context.Context) ([]Group, error) {
-	res, err := g.client.Get(ctx, g.URL(""), nil)
+	ctx = WithOperation(ctx, "groups.list")
+	res, err := g.client.Get(ctx, g.url(ctx, ""), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get groups: %s", err)
+		return nil, fmt.Errorf("failed to get groups: %w", err)
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return nil, g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return nil, g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get database groups: received status code%w", parseResponseError(res))
+		return nil, fmt.Errorf("failed to get database groups: %w", parseResponseError(res))
 	}
 
 	type ListResponse struct {
@@ -39,22 +55,23 @@ func (g *GroupsClient) List(ctx context.Context) ([]Group, error) {
 }
 
 func (g *GroupsClient) Get(ctx context.Context, name string) (Group, error) {
-	res, err := g.client.Get(ctx, g.URL("/"+name), nil)
+	ctx = WithOperation(ctx, "groups.get")
+	res, err := g.client.Get(ctx, g.url(ctx, "/"+name), nil)
 	if err != nil {
 		return Group{}, fmt.Errorf("failed to get group %s: %w", name, err)
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return Group{}, g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return Group{}, g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode == http.StatusNotFound {
-		return Group{}, fmt.Errorf("group %s was not found", name)
+		return Group{}, fmt.Errorf("failed to get group %s: %w", name, parseResponseError(res))
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return Group{}, fmt.Errorf("failed to get database group: received status code%w", parseResponseError(res))
+		return Group{}, fmt.Errorf("failed to get database group: %w", parseResponseError(res))
 	}
 
 	type Response struct {
@@ -65,47 +82,49 @@ func (g *GroupsClient) Get(ctx context.Context, name string) (Group, error) {
 }
 
 func (g *GroupsClient) Delete(ctx context.Context, group string) error {
-	url := g.URL("/" + group)
+	ctx = WithOperation(ctx, "groups.delete")
+	url := g.url(ctx, "/"+group)
 	res, err := g.client.Delete(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete group: %s", err)
+		return fmt.Errorf("failed to delete group: %w", err)
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("group %s not found", group)
+		return fmt.Errorf("failed to delete group %s: %w", group, parseResponseError(res))
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to delete group: received status code%w", parseResponseError(res))
+		return fmt.Errorf("failed to delete group: %w", parseResponseError(res))
 	}
 
 	return nil
 }
 
 func (g *GroupsClient) Create(ctx context.Context, name, location, version string) error {
+	ctx = WithOperation(ctx, "groups.create")
 	type Body struct{ Name, Location, Version string }
 	body, err := marshal(Body{name, location, version})
 	if err != nil {
 		return fmt.Errorf("could not serialize request body: %w", err)
 	}
 
-	res, err := g.client.Post(ctx, g.URL(""), body)
+	res, err := g.client.Post(ctx, g.url(ctx, ""), body)
 	if err != nil {
-		return fmt.Errorf("failed to create group: %s", err)
+		return fmt.Errorf("failed to create group: %w", err)
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode == http.StatusUnprocessableEntity {
-		return fmt.Errorf("group name '%s' is not available", name)
+		return fmt.Errorf("group name '%s' is not available: %w", name, parseResponseError(res))
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -115,14 +134,15 @@ func (g *GroupsClient) Create(ctx context.Context, name, location, version strin
 }
 
 func (g *GroupsClient) Unarchive(ctx context.Context, name string) error {
-	res, err := g.client.Post(ctx, g.URL("/"+name+"/unarchive"), nil)
+	ctx = WithOperation(ctx, "groups.unarchive")
+	res, err := g.client.Post(ctx, g.url(ctx, "/"+name+"/unarchive"), nil)
 	if err != nil {
-		return fmt.Errorf("failed to unarchive group: %s", err)
+		return fmt.Errorf("failed to unarchive group: %w", err)
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -132,14 +152,15 @@ func (g *GroupsClient) Unarchive(ctx context.Context, name string) error {
 }
 
 func (g *GroupsClient) AddLocation(ctx context.Context, name, location string) error {
-	res, err := g.client.Post(ctx, g.URL("/"+name+"/locations/"+location), nil)
+	ctx = WithOperation(ctx, "groups.add_location")
+	res, err := g.client.Post(ctx, g.url(ctx, "/"+name+"/locations/"+location), nil)
 	if err != nil {
-		return fmt.Errorf("failed to post group location request: %s", err)
+		return fmt.Errorf("failed to post group location request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -149,14 +170,15 @@ func (g *GroupsClient) AddLocation(ctx context.Context, name, location string) e
 }
 
 func (g *GroupsClient) RemoveLocation(ctx context.Context, name, location string) error {
-	res, err := g.client.Delete(ctx, g.URL("/"+name+"/locations/"+location), nil)
+	ctx = WithOperation(ctx, "groups.remove_location")
+	res, err := g.client.Delete(ctx, g.url(ctx, "/"+name+"/locations/"+location), nil)
 	if err != nil {
-		return fmt.Errorf("failed to post group location request: %s", err)
+		return fmt.Errorf("failed to post group location request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -166,14 +188,15 @@ func (g *GroupsClient) RemoveLocation(ctx context.Context, name, location string
 }
 
 func (g *GroupsClient) WaitLocation(ctx context.Context, name, location string) error {
-	res, err := g.client.Get(ctx, g.URL("/"+name+"/locations/"+location+"/wait"), nil)
+	ctx = WithOperation(ctx, "groups.wait_location")
+	res, err := g.client.Get(ctx, g.url(ctx, "/"+name+"/locations/"+location+"/wait"), nil)
 	if err != nil {
-		return fmt.Errorf("failed to send wait location request: %s", err)
+		return fmt.Errorf("failed to send wait location request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -195,11 +218,12 @@ type GroupTokenRequest struct {
 }
 
 func (g *GroupsClient) Token(ctx context.Context, group string, expiration string, readOnly bool, permissions *PermissionsClaim) (string, error) {
+	ctx = WithOperation(ctx, "groups.token")
 	authorization := ""
 	if readOnly {
 		authorization = "&authorization=read-only"
 	}
-	url := g.URL(fmt.Sprintf("/%s/auth/tokens?expiration=%s%s", group, expiration, authorization))
+	url := g.url(ctx, fmt.Sprintf("/%s/auth/tokens?expiration=%s%s", group, expiration, authorization))
 
 	req := GroupTokenRequest{permissions}
 	body, err := marshal(req)
@@ -213,8 +237,8 @@ func (g *GroupsClient) Token(ctx context.Context, group string, expiration strin
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return "", g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return "", g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -230,15 +254,16 @@ func (g *GroupsClient) Token(ctx context.Context, group string, expiration strin
 }
 
 func (g *GroupsClient) Rotate(ctx context.Context, group string) error {
-	url := g.URL(fmt.Sprintf("/%s/auth/rotate", group))
+	ctx = WithOperation(ctx, "groups.rotate")
+	url := g.url(ctx, fmt.Sprintf("/%s/auth/rotate", group))
 	res, err := g.client.Post(ctx, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to rotate database keys: %w", err)
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -249,21 +274,22 @@ func (g *GroupsClient) Rotate(ctx context.Context, group string) error {
 }
 
 func (g *GroupsClient) Update(ctx context.Context, group string, version, extensions string) error {
+	ctx = WithOperation(ctx, "groups.update")
 	type Body struct{ Version, Extensions string }
 	body, err := marshal(Body{version, extensions})
 	if err != nil {
 		return fmt.Errorf("could not serialize request body: %w", err)
 	}
 
-	url := g.URL(fmt.Sprintf("/%s/update", group))
+	url := g.url(ctx, fmt.Sprintf("/%s/update", group))
 	res, err := g.client.Post(ctx, url, body)
 	if err != nil {
 		return fmt.Errorf("failed to rotate database keys: %w", err)
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -274,6 +300,7 @@ func (g *GroupsClient) Update(ctx context.Context, group string, version, extens
 }
 
 func (g *GroupsClient) Transfer(ctx context.Context, group string, to string) error {
+	ctx = WithOperation(ctx, "groups.transfer")
 	type Body struct {
 		Organization string `json:"organization"`
 	}
@@ -282,29 +309,28 @@ func (g *GroupsClient) Transfer(ctx context.Context, group string, to string) er
 		return fmt.Errorf("could not serialize request body: %w", err)
 	}
 
-	url := g.URL(fmt.Sprintf("/%s/transfer", group))
+	url := g.url(ctx, fmt.Sprintf("/%s/transfer", group))
 	res, err := g.client.Post(ctx, url, body)
 	if err != nil {
 		return fmt.Errorf("failed to transfer group: %w", err)
 	}
 	defer res.Body.Close()
 
-	if g.client.isNotMemberErr(res.StatusCode) {
-		return g.client.notMemberErr()
+	if g.client.isNotMemberErr(res) {
+		return g.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
-		err := parseResponseError(res)
-		return fmt.Errorf("failed to transfer group: %w", err)
+		return fmt.Errorf("failed to transfer group: %w", parseResponseError(res))
 	}
 
 	return nil
 }
 
 func (g *GroupsClient) URL(suffix string) string {
-	prefix := "/v1"
-	if g.client.Org != "" {
-		prefix = "/v1/organizations/" + g.client.Org
-	}
-	return prefix + "/groups" + suffix
+	return g.url(context.Background(), suffix)
+}
+
+func (g *GroupsClient) url(ctx context.Context, suffix string) string {
+	return g.client.orgPrefix(ctx) + "/groups" + suffix
 }
@@ -2,7 +2,6 @@ package turso
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 )
@@ -13,31 +12,52 @@ type Instance struct {
 	Type     string
 	Region   string
 	Hostname string
+
+	// Extra holds fields returned by the API that are not modeled above.
+	Extra Extra `json:"-"`
+}
+
+func (i *Instance) UnmarshalJSON(data []byte) error {
+	type instance Instance
+	extra, err := unmarshalExtra(data, (*instance)(i))
+	i.Extra = extra
+	return err
+}
+
+func (i Instance) MarshalJSON() ([]byte, error) {
+	type instance Instance
+	return marshalExtra(instance(i), i.Extra)
 }
 
 type InstancesClient client
 
 type CreateInstanceLocationError struct {
-	err string
+	err   string
+	cause error
 }
 
 func (e *CreateInstanceLocationError) Error() string {
 	return e.err
 }
 
+func (e *CreateInstanceLocationError) Unwrap() error {
+	return e.cause
+}
+
 func (c *InstancesClient) List(ctx context.Context, db string) ([]Instance, error) {
-	res, err := c.client.Get(ctx, c.URL(db, ""), nil)
+	ctx = WithOperation(ctx, "instances.list")
+	res, err := c.client.Get(ctx, c.url(ctx, db, ""), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list instances of %s: %s", db, err)
+		return nil, fmt.Errorf("failed to list instances of %s: %w", db, err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return nil, c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return nil, c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response with status code %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to list instances of %s: %w", db, parseResponseError(res))
 	}
 
 	type ListResponse struct{ Instances []Instance }
@@ -50,35 +70,27 @@ func (c *InstancesClient) List(ctx context.Context, db string) ([]Instance, erro
 }
 
 func (c *InstancesClient) Delete(ctx context.Context, db, instance string) error {
-	url := c.URL(db, "/"+instance)
+	ctx = WithOperation(ctx, "instances.delete")
+	url := c.url(ctx, db, "/"+instance)
 	res, err := c.client.Delete(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to destroy instances %s of %s: %s", instance, db, err)
+		return fmt.Errorf("failed to destroy instances %s of %s: %w", instance, db, err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return c.client.notMemberErr()
-	}
-
-	if res.StatusCode == http.StatusBadRequest {
-		body, _ := unmarshal[struct{ Error string }](res)
-		return errors.New(body.Error)
-	}
-
-	if res.StatusCode == http.StatusNotFound {
-		body, _ := unmarshal[struct{ Error string }](res)
-		return errors.New(body.Error)
+	if c.client.isNotMemberErr(res) {
+		return c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("response with status code %d", res.StatusCode)
+		return parseResponseError(res)
 	}
 
 	return nil
 }
 
 func (c *InstancesClient) Create(ctx context.Context, dbName, location string) (*Instance, error) {
+	ctx = WithOperation(ctx, "instances.create")
 	type Body struct {
 		Location string
 	}
@@ -87,19 +99,19 @@ func (c *InstancesClient) Create(ctx context.Context, dbName, location string) (
 		return nil, fmt.Errorf("could not serialize request body: %w", err)
 	}
 
-	url := c.URL(dbName, "")
+	url := c.url(ctx, dbName, "")
 	res, err := c.client.Post(ctx, url, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create new instances for %s: %s", dbName, err)
+		return nil, fmt.Errorf("failed to create new instances for %s: %w", dbName, err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return nil, c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return nil, c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode >= http.StatusInternalServerError {
-		return nil, &CreateInstanceLocationError{fmt.Sprintf("failed to create new instance: %s", res.Status)}
+		return nil, &CreateInstanceLocationError{fmt.Sprintf("failed to create new instance: %s", res.Status), parseResponseError(res)}
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -115,38 +127,29 @@ func (c *InstancesClient) Create(ctx context.Context, dbName, location string) (
 }
 
 func (c *InstancesClient) Wait(ctx context.Context, db, instance string) error {
-	url := c.URL(db, "/"+instance+"/wait")
+	ctx = WithOperation(ctx, "instances.wait")
+	url := c.url(ctx, db, "/"+instance+"/wait")
 	res, err := c.client.Get(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to wait for instance %s to of %s be ready: %s", instance, db, err)
+		return fmt.Errorf("failed to wait for instance %s of %s to be ready: %w", instance, db, err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return c.client.notMemberErr()
-	}
-
-	if res.StatusCode == http.StatusBadRequest {
-		body, _ := unmarshal[struct{ Error string }](res)
-		return errors.New(body.Error)
-	}
-
-	if res.StatusCode == http.StatusNotFound {
-		body, _ := unmarshal[struct{ Error string }](res)
-		return errors.New(body.Error)
+	if c.client.isNotMemberErr(res) {
+		return c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("response with status code %d", res.StatusCode)
+		return parseResponseError(res)
 	}
 
 	return nil
 }
 
 func (d *InstancesClient) URL(database, suffix string) string {
-	prefix := "/v1"
-	if d.client.Org != "" {
-		prefix = "/v1/organizations/" + d.client.Org
-	}
-	return fmt.Sprintf("%s/databases/%s/instances%s", prefix, database, suffix)
+	return d.url(context.Background(), database, suffix)
+}
+
+func (d *InstancesClient) url(ctx context.Context, database, suffix string) string {
+	return fmt.Sprintf("%s/databases/%s/instances%s", d.client.orgPrefix(ctx), database, suffix)
 }
@@ -0,0 +1,267 @@
+// Command fakegen generates fakes with call recording for the interfaces
+// declared in a file of package turso. It is run with go generate.
+//
+// Every interface named FooAPI gets a FakeFooAPI struct with one FooFunc field
+// per method. The interface named API, whose methods return the other
+// interfaces, gets a FakeAPI struct aggregating the generated fakes.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const aggregate = "API"
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+type iface struct {
+	name    string
+	methods []method
+}
+
+func main() {
+	in := flag.String("in", "api.go", "file declaring the interfaces")
+	out := flag.String("out", "fakes.go", "file to write the fakes to")
+	flag.Parse()
+
+	src, err := generate(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(path string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := importPath[strings.LastIndex(importPath, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = importPath
+	}
+
+	var ifaces []iface
+	var api *iface
+	used := map[string]bool{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			interfaceType, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok || !strings.HasSuffix(typeSpec.Name.Name, aggregate) {
+				continue
+			}
+			i := iface{name: typeSpec.Name.Name}
+			for _, field := range interfaceType.Methods.List {
+				fn, ok := field.Type.(*ast.FuncType)
+				if !ok || len(field.Names) == 0 {
+					return nil, fmt.Errorf("%s: embedded interfaces are not supported", i.name)
+				}
+				collectPackages(fn, used)
+				i.methods = append(i.methods, parseMethod(fset, field.Names[0].Name, fn))
+			}
+			if i.name == aggregate {
+				api = &i
+			} else {
+				ifaces = append(ifaces, i)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by fakegen from %s. DO NOT EDIT.\n\n", filepath.Base(path))
+	fmt.Fprintf(&buf, "package %s\n\n", file.Name.Name)
+	var paths []string
+	for name := range used {
+		if importPath, ok := imports[name]; ok {
+			paths = append(paths, strconv.Quote(importPath))
+		}
+	}
+	sort.Strings(paths)
+	if len(paths) > 0 {
+		fmt.Fprintf(&buf, "import (\n%s\n)\n", strings.Join(paths, "\n"))
+	}
+
+	if api != nil {
+		writeAggregate(&buf, *api)
+	}
+	for _, i := range ifaces {
+		writeFake(&buf, i)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+func parseMethod(fset *token.FileSet, name string, fn *ast.FuncType) method {
+	m := method{name: name}
+	for idx, field := range fn.Params.List {
+		typ := field.Type
+		variadic := false
+		if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+			typ, variadic = ellipsis.Elt, true
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("p%d", idx))}
+		}
+		for _, n := range names {
+			m.params = append(m.params, param{name: n.Name, typ: exprString(fset, typ), variadic: variadic})
+		}
+	}
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			for range max(len(field.Names), 1) {
+				m.results = append(m.results, exprString(fset, field.Type))
+			}
+		}
+	}
+	return m
+}
+
+func collectPackages(node ast.Node, used map[string]bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+}
+
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, expr)
+	return buf.String()
+}
+
+func writeAggregate(buf *bytes.Buffer, api iface) {
+	fmt.Fprintf(buf, "\n// Fake%s is an %s whose sub-clients are fakes recording their calls.\n", api.name, api.name)
+	fmt.Fprintf(buf, "type Fake%s struct {\n", api.name)
+	for _, m := range api.methods {
+		fmt.Fprintf(buf, "%s *Fake%s\n", fieldName(m), m.results[0])
+	}
+	fmt.Fprintf(buf, "}\n\nvar _ %s = (*Fake%s)(nil)\n\n", api.name, api.name)
+
+	fmt.Fprintf(buf, "// NewFake%s returns a Fake%s with every sub-client fake initialized.\n", api.name, api.name)
+	fmt.Fprintf(buf, "func NewFake%s() *Fake%s {\nreturn &Fake%s{\n", api.name, api.name, api.name)
+	for _, m := range api.methods {
+		fmt.Fprintf(buf, "%s: &Fake%s{},\n", fieldName(m), m.results[0])
+	}
+	fmt.Fprintf(buf, "}\n}\n")
+
+	for _, m := range api.methods {
+		fmt.Fprintf(buf, "\nfunc (f *Fake%s) %s() %s { return f.%s }\n", api.name, m.name, m.results[0], fieldName(m))
+	}
+}
+
+// fieldName names the field of FakeAPI holding the fake returned by m, e.g.
+// Databases for DatabasesAPI.
+func fieldName(m method) string {
+	return strings.TrimSuffix(m.name, aggregate)
+}
+
+func writeFake(buf *bytes.Buffer, i iface) {
+	fake := "Fake" + i.name
+	fmt.Fprintf(buf, "\n// %s is a fake %s recording its calls. Each method returns the\n", fake, i.name)
+	fmt.Fprintf(buf, "// results of the matching Func field when it is set, and zero values otherwise.\n")
+	fmt.Fprintf(buf, "type %s struct {\nFakeCalls\n\n", fake)
+	for _, m := range i.methods {
+		fmt.Fprintf(buf, "%sFunc func%s\n", m.name, signature(m))
+	}
+	fmt.Fprintf(buf, "}\n\nvar _ %s = (*%s)(nil)\n", i.name, fake)
+
+	for _, m := range i.methods {
+		var args, recorded []string
+		for _, p := range m.params {
+			arg := p.name
+			if p.variadic {
+				arg += "..."
+			}
+			args = append(args, arg)
+			if p.typ != "context.Context" {
+				recorded = append(recorded, p.name)
+			}
+		}
+		fmt.Fprintf(buf, "\nfunc (f *%s) %s%s {\n", fake, m.name, signature(m))
+		fmt.Fprintf(buf, "f.record(%s)\n", strings.Join(append([]string{strconv.Quote(m.name)}, recorded...), ", "))
+
+		call := fmt.Sprintf("f.%sFunc(%s)", m.name, strings.Join(args, ", "))
+		if len(m.results) == 0 {
+			fmt.Fprintf(buf, "if f.%sFunc != nil {\n%s\n}\n}\n", m.name, call)
+			continue
+		}
+		fmt.Fprintf(buf, "if f.%sFunc != nil {\nreturn %s\n}\n", m.name, call)
+		var zeros []string
+		for idx, result := range m.results {
+			if result == "error" {
+				zeros = append(zeros, "nil")
+				continue
+			}
+			name := fmt.Sprintf("r%d", idx)
+			fmt.Fprintf(buf, "var %s %s\n", name, result)
+			zeros = append(zeros, name)
+		}
+		fmt.Fprintf(buf, "return %s\n}\n", strings.Join(zeros, ", "))
+	}
+}
+
+func signature(m method) string {
+	var params []string
+	for _, p := range m.params {
+		typ := p.typ
+		if p.variadic {
+			typ = "..." + typ
+		}
+		params = append(params, p.name+" "+typ)
+	}
+	sig := "(" + strings.Join(params, ", ") + ")"
+	switch len(m.results) {
+	case 0:
+	case 1:
+		sig += " " + m.results[0]
+	default:
+		sig += " (" + strings.Join(m.results, ", ") + ")"
+	}
+	return sig
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func Test_Generate_FakesAreUpToDate(t *testing.T) {
+	src, err := generate("../../api.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing, err := os.ReadFile("../../fakes.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, existing) {
+		t.Fatal("fakes.go is out of date, run go generate")
+	}
+}
@@ -15,21 +15,37 @@ type Invoice struct {
 	PaidAt          string `json:"paid_at"`
 	PaymentFailedAt string `json:"payment_failed_at"`
 	InvoicePdf      string `json:"invoice_pdf"`
+
+	// Extra holds fields returned by the API that are not modeled above.
+	Extra Extra `json:"-"`
+}
+
+func (i *Invoice) UnmarshalJSON(data []byte) error {
+	type invoice Invoice
+	extra, err := unmarshalExtra(data, (*invoice)(i))
+	i.Extra = extra
+	return err
+}
+
+func (i Invoice) MarshalJSON() ([]byte, error) {
+	type invoice Invoice
+	return marshalExtra(invoice(i), i.Extra)
 }
 
 func (c *InvoicesClient) List(ctx context.Context) ([]Invoice, error) {
-	res, err := c.client.Get(ctx, c.URL(""), nil)
+	ctx = WithOperation(ctx, "invoices.list")
+	res, err := c.client.Get(ctx, c.url(ctx, ""), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get invoices: %w", err)
 	}
 	defer res.Body.Close()
 
-	if c.client.isNotMemberErr(res.StatusCode) {
-		return nil, c.client.notMemberErr()
+	if c.client.isNotMemberErr(res) {
+		return nil, c.client.notMemberErr(res)
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get invoices: received status code%w", parseResponseError(res))
+		return nil, fmt.Errorf("failed to get invoices: %w", parseResponseError(res))
 	}
 
 	type ListResponse struct {
@@ -40,9 +56,9 @@ func (c *InvoicesClient) List(ctx context.Context) ([]Invoice, error) {
 }
 
 func (c *InvoicesClient) URL(suffix string) string {
-	prefix := "/v1"
-	if c.client.Org != "" {
-		prefix = "/v1/organizations/" + c.client.Org
-	}
-	return prefix + "/invoices" + suffix
+	return c.url(context.Background(), suffix)
+}
+
+func (c *InvoicesClient) url(ctx context.Context, suffix string) string {
+	return c.client.orgPrefix(ctx) + "/invoices" + suffix
 }
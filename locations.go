@@ -15,6 +15,13 @@ type LocationsResponse struct {
 type Location struct {
 	Code        string `json:"code"`
 	Description string `json:"description"`
+
+	// City, Country, Continent and Coordinates are filled in from the location
+	// catalogue. They are empty for locations unknown to it.
+	City        string       `json:"city,omitempty"`
+	Country     string       `json:"country,omitempty"`
+	Continent   Continent    `json:"continent,omitempty"`
+	Coordinates *Coordinates `json:"coordinates,omitempty"`
 }
 
 type LocationResponse struct {
@@ -24,9 +31,10 @@ type LocationResponse struct {
 }
 
 func (c *LocationsClient) List(ctx context.Context) (map[string]string, error) {
+	ctx = WithOperation(ctx, "locations.list")
 	r, err := c.client.Get(ctx, "/v1/locations", nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request locations: %s", err)
+		return nil, fmt.Errorf("failed to request locations: %w", err)
 	}
 	defer r.Body.Close()
 
@@ -44,6 +52,7 @@ func (c *LocationsClient) List(ctx context.Context) (map[string]string, error) {
 }
 
 func (c *LocationsClient) Get(ctx context.Context, location string) (LocationResponse, error) {
+	ctx = WithOperation(ctx, "locations.get")
 	r, err := c.client.Get(ctx, "/v1/locations/"+location, nil)
 	if err != nil {
 		return LocationResponse{}, fmt.Errorf("failed to request location %s: %w", location, err)
@@ -51,7 +60,7 @@ func (c *LocationsClient) Get(ctx context.Context, location string) (LocationRes
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		return LocationResponse{}, fmt.Errorf("failed to get location %s: %s", location, r.Status)
+		return LocationResponse{}, fmt.Errorf("failed to get location %s: %w", location, parseResponseError(r))
 	}
 
 	data, err := unmarshal[struct {
@@ -65,14 +74,34 @@ func (c *LocationsClient) Get(ctx context.Context, location string) (LocationRes
 	return data.Location, nil
 }
 
+// DefaultRegionDiscoveryURL is the endpoint queried by Closest unless
+// WithRegionDiscoveryURL is used.
+const DefaultRegionDiscoveryURL = "https://region.turso.io"
+
+type withRegionDiscoveryURL struct {
+	url string
+}
+
+// WithRegionDiscoveryURL sets the endpoint queried by LocationsClient.Closest,
+// e.g. to reach it through an egress proxy or to fake it in tests. The API
+// token is not sent to it unless it shares the host of the base URL.
+func WithRegionDiscoveryURL(url string) ClientOption {
+	return &withRegionDiscoveryURL{url: url}
+}
+
+func (o *withRegionDiscoveryURL) apply(client *Client) {
+	client.regionDiscoveryURL = o.url
+}
+
 type ClosestLocationResponse struct {
 	Server string
 }
 
 func (c *LocationsClient) Closest(ctx context.Context) (string, error) {
-	r, err := c.client.Get(ctx, "https://region.turso.io", nil)
+	ctx = WithOperation(ctx, "locations.closest")
+	r, err := c.client.Get(ctx, c.client.regionDiscoveryURL, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to request closest: %s", err)
+		return "", fmt.Errorf("failed to request closest: %w", err)
 	}
 	defer r.Body.Close()
 
@@ -88,3 +117,31 @@ func (c *LocationsClient) Closest(ctx context.Context) (string, error) {
 
 	return data.Server, nil
 }
+
+// ClosestTo returns the location closest to the given coordinates, in decimal
+// degrees, among the locations listed by the API. It computes distances
+// locally and is meant as a fallback when Closest cannot reach the region
+// discovery endpoint.
+func (c *LocationsClient) ClosestTo(ctx context.Context, latitude, longitude float64) (string, error) {
+	ctx = WithOperation(ctx, "locations.closest_to")
+	catalogue, err := c.Catalogue(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	nearest := catalogue.NearestTo(Coordinates{Latitude: latitude, Longitude: longitude}, 1)
+	if len(nearest) == 0 {
+		return "", fmt.Errorf("failed to get closest location: no listed location has known coordinates")
+	}
+	return nearest[0].Code, nil
+}
+
+// Catalogue returns the catalogue of the locations listed by the API, using
+// their current descriptions.
+func (c *LocationsClient) Catalogue(ctx context.Context) (*Catalogue, error) {
+	locations, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewCatalogue(locations), nil
+}
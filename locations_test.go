@@ -0,0 +1,162 @@
+package turso_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/tursotest"
+)
+
+func Test_Locations_ClosestDoesNotLeakToken(t *testing.T) {
+	discovery := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("expected no Authorization header, got: %q", auth)
+		}
+		w.Write([]byte(`{"server":"ams","client":"203.0.113.1"}`))
+	}))
+	defer discovery.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithRegionDiscoveryURL(discovery.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closest, err := client.Locations.Closest(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closest != "ams" {
+		t.Fatalf("expected ams, got: %s", closest)
+	}
+}
+
+func Test_Locations_ClosestTo(t *testing.T) {
+	server := tursotest.NewServer(tursotest.WithLocations(map[string]string{
+		"ams": "Amsterdam, Netherlands",
+		"lhr": "London, United Kingdom",
+		"nrt": "Tokyo, Japan",
+	}))
+	defer server.Close()
+	client := server.Client(t)
+
+	tests := map[string]struct {
+		latitude, longitude float64
+		want                string
+	}{
+		"oxford":  {51.75, -1.26, "lhr"},
+		"utrecht": {52.09, 5.12, "ams"},
+		"seoul":   {37.57, 126.98, "nrt"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			closest, err := client.Locations.ClosestTo(context.TODO(), tt.latitude, tt.longitude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if closest != tt.want {
+				t.Fatalf("expected %s, got: %s", tt.want, closest)
+			}
+		})
+	}
+}
+
+func Test_Catalogue_DistanceAndNearest(t *testing.T) {
+	catalogue := turso.NewCatalogue(map[string]string{
+		"ams": "Amsterdam, Netherlands",
+		"fra": "Frankfurt, Germany",
+		"lhr": "London, United Kingdom",
+		"nrt": "Tokyo, Japan",
+		"xyz": "Atlantis, Ocean",
+	})
+
+	distance, err := catalogue.Distance("ams", "lhr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if distance < 350 || distance > 400 {
+		t.Fatalf("expected about 370km between ams and lhr, got %.0fkm", distance)
+	}
+
+	nearest, err := catalogue.Nearest("ams", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nearest) != 2 || nearest[0].Code != "fra" || nearest[1].Code != "lhr" {
+		t.Fatalf("expected fra and lhr, got: %+v", nearest)
+	}
+
+	if _, err := catalogue.Nearest("missing", 1); !errors.Is(err, turso.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+
+	unknown, _ := catalogue.Get("xyz")
+	if unknown.City != "Atlantis" || unknown.Country != "Ocean" || unknown.Coordinates != nil {
+		t.Fatalf("expected city and country from description, got: %+v", unknown)
+	}
+
+	continents := catalogue.ByContinent()
+	if len(continents[turso.Europe]) != 3 || len(continents[turso.Asia]) != 1 {
+		t.Fatalf("unexpected grouping: %+v", continents)
+	}
+}
+
+func Test_Catalogue_DefaultReturnsCopies(t *testing.T) {
+	ams, ok := turso.DefaultCatalogue().Get("ams")
+	if !ok || ams.Coordinates == nil {
+		t.Fatalf("expected ams with coordinates, got: %+v", ams)
+	}
+	want := *ams.Coordinates
+	ams.Coordinates.Latitude = 0
+
+	if again, _ := turso.DefaultCatalogue().Get("ams"); *again.Coordinates != want {
+		t.Fatalf("expected catalogues not to share locations, got: %+v", again.Coordinates)
+	}
+}
+
+func Test_Catalogue_ReturnsCopies(t *testing.T) {
+	catalogue := turso.DefaultCatalogue()
+	want, _ := catalogue.Get("ams")
+	coords := *want.Coordinates
+
+	ams, _ := catalogue.Get("ams")
+	ams.Coordinates.Latitude = 0
+	for _, location := range catalogue.Locations() {
+		location.Coordinates.Latitude = 0
+	}
+	nearest, err := catalogue.Nearest("fra", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, location := range nearest {
+		location.Coordinates.Latitude = 0
+	}
+
+	if again, _ := catalogue.Get("ams"); *again.Coordinates != coords {
+		t.Fatalf("expected the catalogue not to be modified, got: %+v", again.Coordinates)
+	}
+}
+
+func Test_Catalogue_LocationsTable(t *testing.T) {
+	data, err := os.ReadFile("locations.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var locations []turso.Location
+	if err := json.Unmarshal(data, &locations); err != nil {
+		t.Fatalf("invalid locations table: %v", err)
+	}
+	if len(locations) == 0 || len(turso.DefaultCatalogue().Locations()) != len(locations) {
+		t.Fatalf("expected every location of the table in the default catalogue, got %d locations", len(locations))
+	}
+	for _, location := range locations {
+		if location.Code == "" || location.City == "" || location.Continent == "" || location.Coordinates == nil {
+			t.Fatalf("incomplete location in the table: %+v", location)
+		}
+	}
+}
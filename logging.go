@@ -0,0 +1,134 @@
+package turso
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LogLevels configures the levels at which the client logs operations.
+type LogLevels struct {
+	// Success is used for operations that received a non-error response.
+	Success slog.Level
+	// Retry is used for attempts that failed and will be retried.
+	Retry slog.Level
+	// Failure is used for operations that failed with an error or an error
+	// response.
+	Failure slog.Level
+}
+
+var defaultLogLevels = LogLevels{
+	Success: slog.LevelDebug,
+	Retry:   slog.LevelWarn,
+	Failure: slog.LevelError,
+}
+
+type withLogger struct {
+	logger *slog.Logger
+}
+
+// WithLogger logs every operation sent by the client with its method, path,
+// organization, status, latency, attempt and error. Credentials are never
+// logged: request headers and bodies are left out and error messages are
+// scrubbed of the API token and anything that looks like a JWT.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return &withLogger{logger: logger}
+}
+
+func (o *withLogger) apply(client *Client) {
+	client.logger = o.logger
+}
+
+type withLogLevels struct {
+	levels LogLevels
+}
+
+// WithLogLevels overrides the levels used by the logger set with WithLogger.
+// By default successful operations are logged at debug level, retries at warn
+// level and failures at error level.
+func WithLogLevels(levels LogLevels) ClientOption {
+	return &withLogLevels{levels: levels}
+}
+
+func (o *withLogLevels) apply(client *Client) {
+	client.logLevels = o.levels
+}
+
+func (c *Client) logOperation(ctx context.Context, method, path string, attempts int, latency time.Duration, resp *http.Response, err error) {
+	level := c.logLevels.Success
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		level = c.logLevels.Failure
+	}
+	c.log(ctx, level, "turso operation", method, path, resp, err,
+		slog.Int("attempts", attempts),
+		slog.Duration("latency", latency),
+	)
+}
+
+func (c *Client) logRetry(ctx context.Context, method, path string, attempt int, latency, wait time.Duration, resp *http.Response, err error) {
+	c.log(ctx, c.logLevels.Retry, "turso operation retry", method, path, resp, err,
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", latency),
+		slog.Duration("backoff", wait),
+	)
+}
+
+func (c *Client) log(ctx context.Context, level slog.Level, msg, method, path string, resp *http.Response, err error, attrs ...slog.Attr) {
+	if c.logger == nil || !c.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs = append([]slog.Attr{
+		slog.String("operation", OperationFromContext(ctx)),
+		slog.String("method", method),
+		slog.String("path", logPath(path)),
+		slog.String("org", c.org(ctx)),
+	}, attrs...)
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", c.redact(err.Error())))
+	}
+	c.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logPath strips the query string, which is never needed to identify an
+// operation, from a request path.
+func logPath(path string) string {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "[invalid path]"
+	}
+	if u.IsAbs() {
+		return u.Scheme + "://" + u.Host + u.Path
+	}
+	return u.Path
+}
+
+const redacted = "[REDACTED]"
+
+var (
+	// jwtPattern also matches JWTs cut short, e.g. in truncated bodies.
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*(?:\.[A-Za-z0-9_-]*){0,2}`)
+	bearerPattern = regexp.MustCompile(`(?i)bearer\s+\S+`)
+)
+
+// redact removes the API tokens sent by the client, bearer credentials and
+// JWTs, such as database and API tokens, from s.
+func (c *Client) redact(s string) string {
+	c.secrets.each(func(token string) {
+		s = strings.ReplaceAll(s, token, redacted)
+	})
+	return redactCredentials(s)
+}
+
+// redactCredentials removes bearer credentials and JWTs from s.
+func redactCredentials(s string) string {
+	s = bearerPattern.ReplaceAllString(s, "Bearer "+redacted)
+	return jwtPattern.ReplaceAllString(s, redacted)
+}
@@ -0,0 +1,156 @@
+package turso
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OperationMetrics describes a completed operation. It is reported once the
+// response body has been closed, or as soon as the operation fails without a
+// response.
+type OperationMetrics struct {
+	// Operation is the logical operation name, e.g. "databases.create".
+	Operation string
+	Method    string
+	Org       string
+	// StatusCode is the status code of the final response, or 0 when no
+	// response was received.
+	StatusCode int
+	// Duration spans from the first attempt until the response body is closed.
+	Duration time.Duration
+	// BytesSent counts request body bytes over all attempts.
+	BytesSent int64
+	// BytesReceived counts response body bytes read by the caller.
+	BytesReceived int64
+	// Retries is the number of attempts made after the first one.
+	Retries int
+	// Err is the error returned when no response was received.
+	Err error
+}
+
+// MetricsRecorder receives metrics for every operation sent by a client. It
+// must be safe for concurrent use. The prometheus subpackage provides a
+// Prometheus implementation.
+type MetricsRecorder interface {
+	RecordOperation(ctx context.Context, metrics OperationMetrics)
+}
+
+type withMetrics struct {
+	recorder MetricsRecorder
+}
+
+// WithMetrics reports metrics for every operation sent by the client to
+// recorder.
+func WithMetrics(recorder MetricsRecorder) ClientOption {
+	return &withMetrics{recorder: recorder}
+}
+
+func (o *withMetrics) apply(client *Client) {
+	client.metrics = o.recorder
+}
+
+// MemoryMetrics is a MetricsRecorder that keeps every recorded operation in
+// memory, intended for tests.
+type MemoryMetrics struct {
+	mu         sync.Mutex
+	operations []OperationMetrics
+}
+
+func (m *MemoryMetrics) RecordOperation(_ context.Context, metrics OperationMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operations = append(m.operations, metrics)
+}
+
+// Operations returns the operations recorded so far.
+func (m *MemoryMetrics) Operations() []OperationMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]OperationMetrics(nil), m.operations...)
+}
+
+// Reset discards the recorded operations.
+func (m *MemoryMetrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operations = nil
+}
+
+type operationStatsKey struct{}
+
+// operationStats accumulates the bytes sent by the attempts of an operation.
+type operationStats struct {
+	sent atomic.Int64
+}
+
+func (c *Client) withOperationStats(ctx context.Context) (context.Context, *operationStats) {
+	if c.metrics == nil {
+		return ctx, nil
+	}
+	stats := &operationStats{}
+	return context.WithValue(ctx, operationStatsKey{}, stats), stats
+}
+
+// countRequestBody counts the request body bytes read by the transport.
+func countRequestBody(req *http.Request) {
+	stats, ok := req.Context().Value(operationStatsKey{}).(*operationStats)
+	if !ok || req.Body == nil {
+		return
+	}
+	req.Body = &countingReadCloser{ReadCloser: req.Body, count: &stats.sent}
+}
+
+// recordMetrics reports the operation once resp's body is closed, or right
+// away when there is no response.
+func (c *Client) recordMetrics(ctx context.Context, method string, start time.Time, attempts int, stats *operationStats, resp *http.Response, err error) {
+	metrics := OperationMetrics{
+		Operation: OperationFromContext(ctx),
+		Method:    method,
+		Org:       c.org(ctx),
+		BytesSent: stats.sent.Load(),
+		Retries:   max(attempts-1, 0),
+		Err:       err,
+	}
+	if resp == nil {
+		metrics.Duration = time.Since(start)
+		c.metrics.RecordOperation(ctx, metrics)
+		return
+	}
+
+	metrics.StatusCode = resp.StatusCode
+	received := &atomic.Int64{}
+	resp.Body = &countingReadCloser{
+		ReadCloser: resp.Body,
+		count:      received,
+		onClose: func() {
+			metrics.Duration = time.Since(start)
+			metrics.BytesReceived = received.Load()
+			c.metrics.RecordOperation(ctx, metrics)
+		},
+	}
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	count   *atomic.Int64
+	onClose func()
+	once    sync.Once
+}
+
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.count.Add(int64(n))
+	return n, err
+}
+
+func (r *countingReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	if r.onClose != nil {
+		r.once.Do(r.onClose)
+	}
+	return err
+}
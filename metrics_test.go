@@ -0,0 +1,43 @@
+package turso_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alehechka/turso-go"
+)
+
+func Test_Metrics_RecordsOperations(t *testing.T) {
+	const response = `{"group":{"name":"default"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	metrics := &turso.MemoryMetrics{}
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL), turso.WithMetrics(metrics))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Groups.Create(context.TODO(), "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Groups.Get(context.TODO(), "default"); err != nil {
+		t.Fatal(err)
+	}
+
+	operations := metrics.Operations()
+	if len(operations) != 2 {
+		t.Fatalf("expected 2 operations, got %d", len(operations))
+	}
+	create, get := operations[0], operations[1]
+	if create.Operation != "groups.create" || create.StatusCode != http.StatusOK || create.BytesSent == 0 {
+		t.Fatalf("unexpected create metrics: %+v", create)
+	}
+	if get.Operation != "groups.get" || get.Method != http.MethodGet || get.BytesReceived != int64(len(response)) {
+		t.Fatalf("unexpected get metrics: %+v", get)
+	}
+}
@@ -0,0 +1,76 @@
+package turso
+
+import (
+	"context"
+	"net/http"
+)
+
+// Doer sends an HTTP request and returns its response. *http.Client
+// implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts an ordinary function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer used to send requests to the Platform API.
+type Middleware func(next Doer) Doer
+
+type withMiddleware struct {
+	middleware []Middleware
+}
+
+// WithMiddleware adds middleware around every request sent by the client and
+// all of its sub-clients. Middleware is applied in the order it is given, so the
+// first middleware is the outermost one: it sees the request first and the
+// response last. Calling WithMiddleware more than once appends to the chain.
+//
+// The chain runs once per attempt, after rate limiting and inside the retry
+// loop, which means a middleware returning a 5xx response or an error triggers
+// a retry. The logical operation and attempt number of a request are available
+// through OperationFromContext and AttemptFromContext on req.Context().
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return &withMiddleware{middleware: middleware}
+}
+
+func (o *withMiddleware) apply(client *Client) {
+	client.middleware = append(client.middleware, o.middleware...)
+}
+
+func (c *Client) buildTransport() Doer {
+	var transport Doer = c.httpClient
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		transport = c.middleware[i](transport)
+	}
+	return transport
+}
+
+type operationKey struct{}
+
+type attemptKey struct{}
+
+// WithOperation returns a copy of ctx carrying the logical operation name of
+// a request, e.g. "databases.create". Every method of the sub-clients sets it;
+// use it to name requests sent through Client.Do and its helpers.
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// OperationFromContext returns the logical operation name stored in ctx, or an
+// empty string if there is none.
+func OperationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
+
+// AttemptFromContext returns the attempt number, starting at 1, of the request
+// whose context is ctx, or 0 outside of the client's request pipeline.
+func AttemptFromContext(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptKey{}).(int)
+	return attempt
+}
@@ -0,0 +1,63 @@
+package turso_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/alehechka/turso-go"
+)
+
+func Test_Middleware_OrderAndOperation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Values("X-Trace"); !reflect.DeepEqual(got, []string{"outer", "inner"}) {
+			t.Errorf("unexpected middleware headers: %v", got)
+		}
+		w.Write([]byte(`{"group":{"name":"default"}}`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	tag := func(name string) turso.Middleware {
+		return func(next turso.Doer) turso.Doer {
+			return turso.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+":"+turso.OperationFromContext(req.Context()))
+				req.Header.Add("X-Trace", name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL), turso.WithMiddleware(tag("outer")), turso.WithMiddleware(tag("inner")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Groups.Get(context.TODO(), "default"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"outer:groups.get", "inner:groups.get"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("expected %v, got %v", expected, calls)
+	}
+}
+
+func Test_Middleware_ResponseWithoutRequest(t *testing.T) {
+	forbidden := func(next turso.Doer) turso.Doer {
+		return turso.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusForbidden}, nil
+		})
+	}
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl("http://localhost"), turso.WithMiddleware(forbidden))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Databases.List(context.TODO()); !errors.Is(err, turso.ErrNotMember) {
+		t.Fatalf("expected ErrNotMember, got: %v", err)
+	}
+}
@@ -14,12 +14,28 @@ type Organization struct {
 	Type     string `json:"type,omitempty"`
 	StripeID string `json:"stripe_id,omitempty"`
 	Overages bool   `json:"overages,omitempty"`
+
+	// Extra holds fields returned by the API that are not modeled above.
+	Extra Extra `json:"-"`
+}
+
+func (o *Organization) UnmarshalJSON(data []byte) error {
+	type organization Organization
+	extra, err := unmarshalExtra(data, (*organization)(o))
+	o.Extra = extra
+	return err
+}
+
+func (o Organization) MarshalJSON() ([]byte, error) {
+	type organization Organization
+	return marshalExtra(organization(o), o.Extra)
 }
 
 func (c *OrganizationsClient) List(ctx context.Context) ([]Organization, error) {
+	ctx = WithOperation(ctx, "organizations.list")
 	r, err := c.client.Get(ctx, "/v2/organizations", nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request organizations: %s", err)
+		return nil, fmt.Errorf("failed to request organizations: %w", err)
 	}
 	defer r.Body.Close()
 
@@ -40,23 +56,24 @@ func (c *OrganizationsClient) List(ctx context.Context) ([]Organization, error)
 }
 
 func (c *OrganizationsClient) Create(ctx context.Context, name string, stripeId string, dryRun bool) (Organization, error) {
+	ctx = WithOperation(ctx, "organizations.create")
 	body, err := marshal(Organization{Name: name, StripeID: stripeId})
 	if err != nil {
-		return Organization{}, fmt.Errorf("failed to marshall create org request body: %s", err)
+		return Organization{}, fmt.Errorf("failed to marshall create org request body: %w", err)
 	}
 
 	r, err := c.client.Post(ctx, fmt.Sprintf("/v1/organizations?dry_run=%v", dryRun), body)
 	if err != nil {
-		return Organization{}, fmt.Errorf("failed to post organization: %s", err)
+		return Organization{}, fmt.Errorf("failed to post organization: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusConflict {
-		return Organization{}, fmt.Errorf("failed to create organization %s: name already exists", name)
+		return Organization{}, fmt.Errorf("failed to create organization %s: name already exists: %w", name, parseResponseError(r))
 	}
 
 	if r.StatusCode == http.StatusPaymentRequired {
-		return Organization{}, fmt.Errorf("failed to create organization %s: you need to upgrade your plan", name)
+		return Organization{}, fmt.Errorf("failed to create organization %s: you need to upgrade your plan: %w", name, parseResponseError(r))
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -72,14 +89,15 @@ func (c *OrganizationsClient) Create(ctx context.Context, name string, stripeId
 }
 
 func (c *OrganizationsClient) Delete(ctx context.Context, slug string) error {
+	ctx = WithOperation(ctx, "organizations.delete")
 	r, err := c.client.Delete(ctx, "/v1/organizations/"+slug, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete organization: %s", err)
+		return fmt.Errorf("failed to delete organization: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("could not find organization %s", slug)
+		return fmt.Errorf("could not find organization %s: %w", slug, parseResponseError(r))
 	}
 
 	switch r.StatusCode {
@@ -88,7 +106,7 @@ func (c *OrganizationsClient) Delete(ctx context.Context, slug string) error {
 	case http.StatusBadRequest:
 		return parseResponseError(r)
 	case http.StatusForbidden:
-		return fmt.Errorf("you do not have permission to delete organization %s", slug)
+		return fmt.Errorf("you do not have permission to delete organization %s: %w", slug, parseResponseError(r))
 	default:
 		return fmt.Errorf("failed to delete organization: %w", parseResponseError(r))
 	}
@@ -115,10 +133,8 @@ type OrgUsageResponse struct {
 }
 
 func (c *OrganizationsClient) Usage(ctx context.Context) (OrgUsage, error) {
-	prefix := "/v1"
-	if c.client.Org != "" {
-		prefix = "/v1/organizations/" + c.client.Org
-	}
+	ctx = WithOperation(ctx, "organizations.usage")
+	prefix := c.client.orgPrefix(ctx)
 
 	r, err := c.client.Get(ctx, prefix+"/usage", nil)
 	if err != nil {
@@ -127,8 +143,7 @@ func (c *OrganizationsClient) Usage(ctx context.Context) (OrgUsage, error) {
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		err, _ := unmarshal[string](r)
-		return OrgUsage{}, fmt.Errorf("failed to get database usage: %d %s", r.StatusCode, err)
+		return OrgUsage{}, fmt.Errorf("failed to get organization usage: %w", parseResponseError(r))
 	}
 
 	body, err := unmarshal[OrgUsageResponse](r)
@@ -139,14 +154,15 @@ func (c *OrganizationsClient) Usage(ctx context.Context) (OrgUsage, error) {
 }
 
 func (c *OrganizationsClient) SetOverages(ctx context.Context, slug string, toggle bool) error {
+	ctx = WithOperation(ctx, "organizations.set_overages")
 	path := "/v1/organizations/" + slug
 	body, err := marshal(map[string]bool{"overages": toggle})
 	if err != nil {
-		return fmt.Errorf("failed to marshall set overages request body: %s", err)
+		return fmt.Errorf("failed to marshall set overages request body: %w", err)
 	}
 	r, err := c.client.Patch(ctx, path, body)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to set overages: %w", err)
 	}
 	defer r.Body.Close()
 
@@ -169,19 +185,20 @@ type Invite struct {
 }
 
 func (c *OrganizationsClient) ListMembers(ctx context.Context) ([]Member, error) {
-	url, err := c.MembersURL("")
+	ctx = WithOperation(ctx, "organizations.list_members")
+	url, err := c.membersURL(ctx, "")
 	if err != nil {
 		return nil, err
 	}
 
 	r, err := c.client.Get(ctx, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request organization members: %s", err)
+		return nil, fmt.Errorf("failed to request organization members: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("only organization admins or owners can list members")
+		return nil, fmt.Errorf("only organization admins or owners can list members: %w", parseResponseError(r))
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -197,24 +214,25 @@ func (c *OrganizationsClient) ListMembers(ctx context.Context) ([]Member, error)
 }
 
 func (c *OrganizationsClient) AddMember(ctx context.Context, username, role string) error {
-	url, err := c.MembersURL("")
+	ctx = WithOperation(ctx, "organizations.add_member")
+	url, err := c.membersURL(ctx, "")
 	if err != nil {
 		return err
 	}
 
 	body, err := marshal(Member{Name: username, Role: role})
 	if err != nil {
-		return fmt.Errorf("failed to marshall add member request body: %s", err)
+		return fmt.Errorf("failed to marshall add member request body: %w", err)
 	}
 
 	r, err := c.client.Post(ctx, url, body)
 	if err != nil {
-		return fmt.Errorf("failed to post organization member: %s", err)
+		return fmt.Errorf("failed to post organization member: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return fmt.Errorf("only organization admins or owners can add members")
+		return fmt.Errorf("only organization admins or owners can add members: %w", parseResponseError(r))
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -225,21 +243,22 @@ func (c *OrganizationsClient) AddMember(ctx context.Context, username, role stri
 }
 
 func (c *OrganizationsClient) InviteMember(ctx context.Context, email, role string) error {
-	prefix := "/v1/organizations/" + c.client.Org
+	ctx = WithOperation(ctx, "organizations.invite_member")
+	prefix := "/v1/organizations/" + c.client.org(ctx)
 
 	body, err := marshal(Invite{Email: email, Role: role})
 	if err != nil {
-		return fmt.Errorf("failed to marshall invite email request body: %s", err)
+		return fmt.Errorf("failed to marshall invite email request body: %w", err)
 	}
 
 	r, err := c.client.Post(ctx, prefix+"/invite", body)
 	if err != nil {
-		return fmt.Errorf("failed to invite organization member: %s", err)
+		return fmt.Errorf("failed to invite organization member: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return fmt.Errorf("only organization admins or owners can invite members")
+		return fmt.Errorf("only organization admins or owners can invite members: %w", parseResponseError(r))
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -250,20 +269,21 @@ func (c *OrganizationsClient) InviteMember(ctx context.Context, email, role stri
 }
 
 func (c *OrganizationsClient) DeleteInvite(ctx context.Context, email string) error {
-	prefix := "/v1/organizations/" + c.client.Org
+	ctx = WithOperation(ctx, "organizations.delete_invite")
+	prefix := "/v1/organizations/" + c.client.org(ctx)
 
 	r, err := c.client.Delete(ctx, prefix+"/invites/"+email, nil)
 	if err != nil {
-		return fmt.Errorf("failed to remove pending invite: %s", err)
+		return fmt.Errorf("failed to remove pending invite: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return fmt.Errorf("only organization admins or owners can invite members")
+		return fmt.Errorf("only organization admins or owners can invite members: %w", parseResponseError(r))
 	}
 
 	if r.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("invite for %s not found", email)
+		return fmt.Errorf("failed to delete pending invite for %s: %w", email, parseResponseError(r))
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -274,16 +294,17 @@ func (c *OrganizationsClient) DeleteInvite(ctx context.Context, email string) er
 }
 
 func (c *OrganizationsClient) ListInvites(ctx context.Context) ([]Invite, error) {
-	prefix := "/v1/organizations/" + c.client.Org
+	ctx = WithOperation(ctx, "organizations.list_invites")
+	prefix := "/v1/organizations/" + c.client.org(ctx)
 
 	r, err := c.client.Get(ctx, prefix+"/invites", nil)
 	if err != nil {
-		return []Invite{}, fmt.Errorf("failed to list invites: %s", err)
+		return []Invite{}, fmt.Errorf("failed to list invites: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return []Invite{}, fmt.Errorf("only organization admins or owners can list invites")
+		return []Invite{}, fmt.Errorf("only organization admins or owners can list invites: %w", parseResponseError(r))
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -301,19 +322,20 @@ func (c *OrganizationsClient) ListInvites(ctx context.Context) ([]Invite, error)
 }
 
 func (c *OrganizationsClient) RemoveMember(ctx context.Context, username string) error {
-	url, err := c.MembersURL("/" + username)
+	ctx = WithOperation(ctx, "organizations.remove_member")
+	url, err := c.membersURL(ctx, "/"+username)
 	if err != nil {
 		return err
 	}
 
 	r, err := c.client.Delete(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete organization member: %s", err)
+		return fmt.Errorf("failed to delete organization member: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return fmt.Errorf("only organization admins or owners can remove members")
+		return fmt.Errorf("only organization admins or owners can remove members: %w", parseResponseError(r))
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -324,5 +346,9 @@ func (c *OrganizationsClient) RemoveMember(ctx context.Context, username string)
 }
 
 func (c *OrganizationsClient) MembersURL(suffix string) (string, error) {
-	return "/v1/organizations/" + c.client.Org + "/members" + suffix, nil
+	return c.membersURL(context.Background(), suffix)
+}
+
+func (c *OrganizationsClient) membersURL(ctx context.Context, suffix string) (string, error) {
+	return "/v1/organizations/" + c.client.org(ctx) + "/members" + suffix, nil
 }
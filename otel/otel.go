@@ -0,0 +1,170 @@
+// Package otel traces turso clients with OpenTelemetry.
+//
+// Every SDK operation is recorded as a span named after the operation, e.g.
+// "turso.databases.create", with one child client span per HTTP attempt so
+// retries are visible. The trace context is propagated to the Platform API
+// through the request headers.
+//
+//	client, err := turso.New(token, org, otel.WithTracing())
+package otel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alehechka/turso-go"
+	global "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/alehechka/turso-go/otel"
+
+// Attribute keys set on operation spans in addition to the HTTP semantic
+// convention attributes.
+const (
+	OperationKey    = attribute.Key("turso.operation")
+	OrganizationKey = attribute.Key("turso.organization")
+	DatabaseKey     = attribute.Key("turso.database")
+	GroupKey        = attribute.Key("turso.group")
+	LocationKey     = attribute.Key("turso.location")
+	InstanceKey     = attribute.Key("turso.instance")
+)
+
+// Option configures a Tracer.
+type Option func(*Tracer)
+
+// WithTracerProvider sets the provider used to create spans. The global
+// provider is used by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(t *Tracer) {
+		t.provider = provider
+	}
+}
+
+// WithPropagator sets the propagator used to inject the trace context into
+// request headers. The global propagator is used by default.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(t *Tracer) {
+		t.propagator = propagator
+	}
+}
+
+// Tracer implements turso.Tracer with OpenTelemetry.
+type Tracer struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+	tracer     trace.Tracer
+}
+
+// NewTracer creates a Tracer to be passed to turso.WithTracer.
+func NewTracer(opts ...Option) *Tracer {
+	t := &Tracer{}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.provider == nil {
+		t.provider = global.GetTracerProvider()
+	}
+	if t.propagator == nil {
+		t.propagator = global.GetTextMapPropagator()
+	}
+	t.tracer = t.provider.Tracer(instrumentationName)
+	return t
+}
+
+// WithTracing is a shorthand for turso.WithTracer(NewTracer(opts...)).
+func WithTracing(opts ...Option) turso.ClientOption {
+	return turso.WithTracer(NewTracer(opts...))
+}
+
+func (t *Tracer) Start(ctx context.Context, info turso.SpanInfo) (context.Context, turso.Span) {
+	if info.Attempt == 0 {
+		name := "turso.request"
+		if info.Operation != "" {
+			name = "turso." + info.Operation
+		}
+		ctx, span := t.tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindInternal),
+			trace.WithAttributes(operationAttributes(info)...),
+		)
+		return ctx, &tracedSpan{span}
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", info.Method),
+		attribute.String("url.path", info.Path),
+	}
+	if info.Attempt > 1 {
+		attrs = append(attrs, attribute.Int("http.request.resend_count", info.Attempt-1))
+	}
+	ctx, span := t.tracer.Start(ctx, info.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx, &tracedSpan{span}
+}
+
+func (t *Tracer) Inject(ctx context.Context, header http.Header) {
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+type tracedSpan struct {
+	span trace.Span
+}
+
+func (s *tracedSpan) End(resp *http.Response, err error) {
+	if resp != nil {
+		s.span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			s.span.SetAttributes(attribute.String("error.type", fmt.Sprint(resp.StatusCode)))
+			s.span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	if err != nil {
+		s.span.SetAttributes(attribute.String("error.type", fmt.Sprintf("%T", err)))
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+// operationAttributes extracts the organization and the resources targeted by
+// an operation from its path, e.g.
+// /v1/organizations/{org}/groups/{group}/locations/{location}.
+func operationAttributes(info turso.SpanInfo) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		OperationKey.String(info.Operation),
+		attribute.String("http.request.method", info.Method),
+	}
+
+	org := info.Org
+	segments := strings.Split(strings.Trim(info.Path, "/"), "/")
+	if len(segments) > 0 && strings.HasPrefix(segments[0], "v") {
+		segments = segments[1:]
+	}
+	if len(segments) > 1 && segments[0] == "organizations" {
+		org = segments[1]
+		segments = segments[2:]
+	}
+	if org != "" {
+		attrs = append(attrs, OrganizationKey.String(org))
+	}
+
+	keys := map[string]attribute.Key{
+		"databases": DatabaseKey,
+		"groups":    GroupKey,
+		"locations": LocationKey,
+		"instances": InstanceKey,
+	}
+	for i := 0; i+1 < len(segments); i += 2 {
+		if key, ok := keys[segments[i]]; ok {
+			attrs = append(attrs, key.String(segments[i+1]))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,71 @@
+package otel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_Tracer_RecordsOperationAndAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Traceparent") == "" {
+			t.Error("expected trace context to be propagated")
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client, err := turso.New("my-token", "my-org",
+		turso.WithBaseUrl(server.URL),
+		turso.WithRetryPolicy(turso.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		otel.WithTracing(otel.WithTracerProvider(provider), otel.WithPropagator(propagation.TraceContext{})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Groups.WaitLocation(context.TODO(), "default", "ams"); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected an operation span and two attempt spans, got %d", len(spans))
+	}
+
+	operation := spans[2]
+	if operation.Name() != "turso.groups.wait_location" {
+		t.Fatalf("unexpected operation span name: %s", operation.Name())
+	}
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, attr := range operation.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	if attrs[otel.OrganizationKey].AsString() != "my-org" || attrs[otel.GroupKey].AsString() != "default" || attrs[otel.LocationKey].AsString() != "ams" {
+		t.Fatalf("unexpected operation attributes: %v", operation.Attributes())
+	}
+
+	for _, attempt := range spans[:2] {
+		if attempt.Parent().SpanID() != operation.SpanContext().SpanID() {
+			t.Fatal("expected attempt spans to be children of the operation span")
+		}
+	}
+}
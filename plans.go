@@ -10,18 +10,52 @@ type PlansClient client
 type Plan struct {
 	Name   string `json:"name"`
 	Price  string `json:"price"`
-	Quotas struct {
-		RowsRead    uint64 `json:"rowsRead"`
-		RowsWritten uint64 `json:"rowsWritten"`
-		Databases   uint64 `json:"databases"`
-		BytesSynced uint64 `json:"bytesSynced"`
-		Locations   uint64 `json:"locations"`
-		Storage     uint64 `json:"storage"`
-		Groups      uint64 `json:"groups"`
-	}
+	Quotas PlanQuotas
+
+	// Extra holds fields returned by the API that are not modeled above.
+	Extra Extra `json:"-"`
+}
+
+// PlanQuotas are the limits of a plan. Storage and BytesSynced are in bytes.
+type PlanQuotas struct {
+	RowsRead    uint64 `json:"rowsRead"`
+	RowsWritten uint64 `json:"rowsWritten"`
+	Databases   uint64 `json:"databases"`
+	BytesSynced uint64 `json:"bytesSynced"`
+	Locations   uint64 `json:"locations"`
+	Storage     uint64 `json:"storage"`
+	Groups      uint64 `json:"groups"`
+
+	// Extra holds fields returned by the API that are not modeled above.
+	Extra Extra `json:"-"`
+}
+
+func (p *Plan) UnmarshalJSON(data []byte) error {
+	type plan Plan
+	extra, err := unmarshalExtra(data, (*plan)(p))
+	p.Extra = extra
+	return err
+}
+
+func (p Plan) MarshalJSON() ([]byte, error) {
+	type plan Plan
+	return marshalExtra(plan(p), p.Extra)
+}
+
+func (q *PlanQuotas) UnmarshalJSON(data []byte) error {
+	type quotas PlanQuotas
+	extra, err := unmarshalExtra(data, (*quotas)(q))
+	q.Extra = extra
+	return err
+}
+
+func (q PlanQuotas) MarshalJSON() ([]byte, error) {
+	type quotas PlanQuotas
+	return marshalExtra(quotas(q), q.Extra)
 }
 
 func (c *PlansClient) List(ctx context.Context) ([]Plan, error) {
+	ctx = WithOperation(ctx, "plans.list")
 	r, err := c.client.Get(ctx, "/v1/plans", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get plan list: %w", err)
@@ -29,7 +63,7 @@ func (c *PlansClient) List(ctx context.Context) ([]Plan, error) {
 	defer r.Body.Close()
 
 	if r.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to list plans with status %s: %v", r.Status, parseResponseError(r))
+		return nil, fmt.Errorf("failed to list plans: %w", parseResponseError(r))
 	}
 
 	resp, err := unmarshal[struct{ Plans []Plan }](r)
@@ -0,0 +1,19 @@
+module github.com/alehechka/turso-go/prometheus
+
+go 1.22.0
+
+require (
+	github.com/alehechka/turso-go v0.0.0-20261016221905-9ac0dd97f690
+	github.com/prometheus/client_golang v1.19.1
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
+	golang.org/x/sys v0.26.0 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+)
@@ -0,0 +1,116 @@
+// Package prometheus exports metrics about turso clients to Prometheus.
+//
+//	recorder := prometheus.NewRecorder()
+//	registry.MustRegister(recorder)
+//	client, err := turso.New(token, org, turso.WithMetrics(recorder))
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/alehechka/turso-go"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// Option configures a Recorder.
+type Option func(*options)
+
+type options struct {
+	namespace string
+	buckets   []float64
+	labels    prom.Labels
+}
+
+// WithNamespace sets the namespace prefixed to every metric name. It
+// defaults to "turso".
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets of the request duration histogram.
+func WithBuckets(buckets []float64) Option {
+	return func(o *options) {
+		o.buckets = buckets
+	}
+}
+
+// WithConstLabels adds constant labels to every metric.
+func WithConstLabels(labels prom.Labels) Option {
+	return func(o *options) {
+		o.labels = labels
+	}
+}
+
+// Recorder implements turso.MetricsRecorder and prometheus.Collector. All
+// metrics are labelled by operation, method and status, where status is the
+// HTTP status code or "error" when no response was received.
+type Recorder struct {
+	requests      *prom.CounterVec
+	duration      *prom.HistogramVec
+	bytesSent     *prom.CounterVec
+	bytesReceived *prom.CounterVec
+	retries       *prom.CounterVec
+}
+
+var _ turso.MetricsRecorder = (*Recorder)(nil)
+var _ prom.Collector = (*Recorder)(nil)
+
+// NewRecorder creates a Recorder. It must be registered with a Prometheus
+// registry to be exported.
+func NewRecorder(opts ...Option) *Recorder {
+	o := &options{namespace: "turso", buckets: prom.DefBuckets}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	labels := []string{"operation", "method", "status"}
+	counter := func(name, help string) *prom.CounterVec {
+		return prom.NewCounterVec(prom.CounterOpts{
+			Namespace: o.namespace, Subsystem: "client", Name: name, Help: help, ConstLabels: o.labels,
+		}, labels)
+	}
+
+	return &Recorder{
+		requests: counter("requests_total", "Number of operations sent to the Turso Platform API."),
+		duration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: o.namespace, Subsystem: "client", Name: "request_duration_seconds",
+			Help: "Duration of operations sent to the Turso Platform API, including retries.", Buckets: o.buckets, ConstLabels: o.labels,
+		}, labels),
+		bytesSent:     counter("sent_bytes_total", "Request body bytes sent to the Turso Platform API."),
+		bytesReceived: counter("received_bytes_total", "Response body bytes received from the Turso Platform API."),
+		retries:       counter("retries_total", "Number of retried attempts sent to the Turso Platform API."),
+	}
+}
+
+func (r *Recorder) RecordOperation(_ context.Context, m turso.OperationMetrics) {
+	status := "error"
+	if m.StatusCode != 0 {
+		status = strconv.Itoa(m.StatusCode)
+	}
+	labels := prom.Labels{"operation": m.Operation, "method": m.Method, "status": status}
+
+	r.requests.With(labels).Inc()
+	r.duration.With(labels).Observe(m.Duration.Seconds())
+	r.bytesSent.With(labels).Add(float64(m.BytesSent))
+	r.bytesReceived.With(labels).Add(float64(m.BytesReceived))
+	r.retries.With(labels).Add(float64(m.Retries))
+}
+
+func (r *Recorder) Describe(ch chan<- *prom.Desc) {
+	r.requests.Describe(ch)
+	r.duration.Describe(ch)
+	r.bytesSent.Describe(ch)
+	r.bytesReceived.Describe(ch)
+	r.retries.Describe(ch)
+}
+
+func (r *Recorder) Collect(ch chan<- prom.Metric) {
+	r.requests.Collect(ch)
+	r.duration.Collect(ch)
+	r.bytesSent.Collect(ch)
+	r.bytesReceived.Collect(ch)
+	r.retries.Collect(ch)
+}
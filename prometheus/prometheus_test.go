@@ -0,0 +1,41 @@
+package prometheus_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/prometheus"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_Recorder_ExportsOperations(t *testing.T) {
+	recorder := prometheus.NewRecorder()
+	registry := prom.NewPedanticRegistry()
+	registry.MustRegister(recorder)
+
+	recorder.RecordOperation(context.TODO(), turso.OperationMetrics{
+		Operation:     "databases.list",
+		Method:        "GET",
+		StatusCode:    200,
+		Duration:      20 * time.Millisecond,
+		BytesReceived: 512,
+		Retries:       1,
+	})
+
+	expected := `
+# HELP turso_client_retries_total Number of retried attempts sent to the Turso Platform API.
+# TYPE turso_client_retries_total counter
+turso_client_retries_total{method="GET",operation="databases.list",status="200"} 1
+# HELP turso_client_received_bytes_total Response body bytes received from the Turso Platform API.
+# TYPE turso_client_received_bytes_total counter
+turso_client_received_bytes_total{method="GET",operation="databases.list",status="200"} 512
+`
+	err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "turso_client_retries_total", "turso_client_received_bytes_total")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
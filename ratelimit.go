@@ -0,0 +1,168 @@
+package turso
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRateLimitDeadline is returned when waiting for the client side rate
+// limiter would exceed the deadline of the request context.
+var ErrRateLimitDeadline = errors.New("rate limit wait would exceed context deadline")
+
+type withRateLimit struct {
+	rate  float64
+	burst int
+}
+
+// WithRateLimit limits the client to rate requests per second with bursts of
+// up to burst requests. The limit is shared by every sub-client and applies to
+// each attempt, including retries.
+func WithRateLimit(rate float64, burst int) ClientOption {
+	return &withRateLimit{rate: rate, burst: burst}
+}
+
+func (o *withRateLimit) apply(client *Client) {
+	client.limiter = client.limiter.with("", newTokenBucket(o.rate, o.burst))
+}
+
+type withEndpointRateLimit struct {
+	class string
+	rate  float64
+	burst int
+}
+
+// WithEndpointRateLimit limits requests to one endpoint class in addition to
+// the limit set by WithRateLimit. The class is the resource segment of the
+// request path that follows the API version and organization, e.g. "databases"
+// for /v1/organizations/{org}/databases/{db}/usage or "locations" for
+// /v1/locations.
+func WithEndpointRateLimit(class string, rate float64, burst int) ClientOption {
+	return &withEndpointRateLimit{class: class, rate: rate, burst: burst}
+}
+
+func (o *withEndpointRateLimit) apply(client *Client) {
+	client.limiter = client.limiter.with(o.class, newTokenBucket(o.rate, o.burst))
+}
+
+// rateLimiter holds the global bucket, stored under the empty class, and the
+// buckets of every endpoint class.
+type rateLimiter struct {
+	buckets map[string]*tokenBucket
+}
+
+func (l *rateLimiter) with(class string, bucket *tokenBucket) *rateLimiter {
+	buckets := map[string]*tokenBucket{class: bucket}
+	if l != nil {
+		for k, v := range l.buckets {
+			if k != class {
+				buckets[k] = v
+			}
+		}
+	}
+	return &rateLimiter{buckets: buckets}
+}
+
+// wait blocks until both the global and the endpoint class buckets allow a
+// request to path.
+func (l *rateLimiter) wait(ctx context.Context, path string) error {
+	if l == nil {
+		return nil
+	}
+
+	var reserved []*tokenBucket
+	if bucket, ok := l.buckets[""]; ok {
+		reserved = append(reserved, bucket)
+	}
+	if class := endpointClass(path); class != "" {
+		if bucket, ok := l.buckets[class]; ok {
+			reserved = append(reserved, bucket)
+		}
+	}
+
+	now := time.Now()
+	var delay time.Duration
+	for _, bucket := range reserved {
+		delay = max(delay, bucket.reserve(now))
+	}
+	cancel := func() {
+		for _, bucket := range reserved {
+			bucket.cancel()
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		cancel()
+		return ErrRateLimitDeadline
+	}
+	if delay == 0 {
+		return nil
+	}
+	if err := sleep(ctx, delay); err != nil {
+		cancel()
+		return err
+	}
+	return nil
+}
+
+func endpointClass(path string) string {
+	u, err := url.Parse(path)
+	if err != nil || u.IsAbs() {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) > 0 && strings.HasPrefix(segments[0], "v") {
+		segments = segments[1:]
+	}
+	if len(segments) > 2 && segments[0] == "organizations" {
+		segments = segments[2:]
+	}
+	if len(segments) == 0 {
+		return ""
+	}
+	return segments[0]
+}
+
+// tokenBucket is a token bucket that refills at rate tokens per second up to
+// burst tokens. Callers reserve a token and wait for the returned delay.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	burst = max(burst, 1)
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate <= 0 {
+		return 0
+	}
+	if now.After(b.last) {
+		if !b.last.IsZero() {
+			b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		}
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.burst, b.tokens+1)
+}
@@ -0,0 +1,59 @@
+package turso_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+)
+
+func Test_RateLimit_SharedAcrossClients(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL), turso.WithRateLimit(1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Databases.List(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	_, err = client.Groups.List(ctx)
+	if !errors.Is(err, turso.ErrRateLimitDeadline) {
+		t.Fatalf("expected rate limit deadline error, got: %v", err)
+	}
+}
+
+func Test_RateLimit_EndpointClass(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL), turso.WithEndpointRateLimit("databases", 1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.Databases.Usage(ctx, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Groups.List(ctx); err != nil {
+		t.Fatalf("expected groups to be unaffected by databases limit, got: %v", err)
+	}
+	if _, err := client.Databases.Usage(ctx, "second"); !errors.Is(err, turso.ErrRateLimitDeadline) {
+		t.Fatalf("expected rate limit deadline error, got: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package turso
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxResponseSize is the largest response body a client reads unless
+// WithMaxResponseSize is used.
+const DefaultMaxResponseSize = 32 << 20
+
+// maxDrainSize caps how much of an unread response body is discarded on close
+// to let the connection be reused. Larger remainders close the connection.
+const maxDrainSize = 256 << 10
+
+// ErrResponseTooLarge is returned when reading a response body larger than the
+// client's maximum response size.
+var ErrResponseTooLarge = errors.New("response body exceeds maximum size")
+
+type withMaxResponseSize struct {
+	size int64
+}
+
+// WithMaxResponseSize limits the size of the response bodies read by the
+// client. Reading past the limit fails with ErrResponseTooLarge. A size of 0
+// or less disables the limit.
+func WithMaxResponseSize(size int64) ClientOption {
+	return &withMaxResponseSize{size: size}
+}
+
+func (o *withMaxResponseSize) apply(client *Client) {
+	client.maxResponseSize = o.size
+}
+
+// wrapResponseBody enforces the maximum response size on resp's body and makes
+// closing it drain what was left unread, so keep-alive connections are reused
+// even when decoding stops early or fails. Responses built by a Doer or
+// middleware without a body get an empty one.
+func (c *Client) wrapResponseBody(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	if resp.Body == nil {
+		resp.Body = http.NoBody
+		return
+	}
+	resp.Body = &responseBody{body: resp.Body, max: c.maxResponseSize, remaining: c.maxResponseSize}
+}
+
+type responseBody struct {
+	body      io.ReadCloser
+	max       int64
+	remaining int64
+}
+
+func (r *responseBody) Read(p []byte) (int, error) {
+	if r.max <= 0 {
+		return r.body.Read(p)
+	}
+	if r.remaining <= 0 {
+		// Probe for a single byte to tell a body of exactly the maximum size
+		// from a larger one.
+		var probe [1]byte
+		if n, err := r.body.Read(probe[:]); n == 0 {
+			return 0, err
+		}
+		return 0, fmt.Errorf("%w of %d bytes", ErrResponseTooLarge, r.max)
+	}
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.body.Read(p)
+	r.remaining -= int64(n)
+	return n, err
+}
+
+func (r *responseBody) Close() error {
+	io.Copy(io.Discard, io.LimitReader(r.body, maxDrainSize))
+	return r.body.Close()
+}
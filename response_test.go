@@ -0,0 +1,134 @@
+package turso_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alehechka/turso-go"
+)
+
+func Test_Response_DecodeErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/usage") {
+			w.Write([]byte(`{"organization":{"databases":[` + strings.Repeat(`{"uuid":"db"},`, 100) + `{}]}}`))
+			return
+		}
+		w.Write([]byte(`{"groups": [{"name": 42}]}`))
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL), turso.WithMaxResponseSize(512))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Groups.List(context.TODO())
+	var decodeErr *turso.DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a DecodeError, got: %v", err)
+	}
+	if decodeErr.Operation != "groups.list" || !strings.Contains(decodeErr.Snippet, `"name": 42`) {
+		t.Fatalf("expected operation and body snippet, got: %v", err)
+	}
+
+	_, err = client.Organizations.Usage(context.TODO())
+	if !errors.Is(err, turso.ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got: %v", err)
+	}
+}
+
+func Test_Response_DecodeErrorRedactsSnippet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jwt":"eyJhbGciOiJFZERTQSIsInR5cCI6IkpXVCJ9.eyJpYXQiOjE3M`))
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Databases.Token(context.TODO(), "my-db", "never", false, nil)
+	var decodeErr *turso.DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a DecodeError, got: %v", err)
+	}
+	if strings.Contains(decodeErr.Snippet, "eyJ") || strings.Contains(err.Error(), "eyJ") {
+		t.Fatalf("expected the token to be redacted, got: %v", err)
+	}
+	if !strings.Contains(decodeErr.Snippet, `"jwt"`) {
+		t.Fatalf("expected the rest of the body to be kept, got: %q", decodeErr.Snippet)
+	}
+}
+
+type eofTracker struct {
+	io.ReadCloser
+	eof       bool
+	closed    *atomic.Int32
+	undrained *atomic.Int32
+}
+
+func (b *eofTracker) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.eof = true
+	}
+	return n, err
+}
+
+func (b *eofTracker) Close() error {
+	b.closed.Add(1)
+	if !b.eof {
+		b.undrained.Add(1)
+	}
+	return b.ReadCloser.Close()
+}
+
+func Test_Response_BodiesAreDrainedForReuse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"` + strings.Repeat("x", 100<<10) + `"}`))
+			return
+		}
+		w.Write([]byte(`{"groups":[]}` + strings.Repeat(" ", 8<<10)))
+	}))
+	defer server.Close()
+
+	var closed, undrained atomic.Int32
+	transport := server.Client().Transport
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := transport.RoundTrip(req)
+		if err == nil {
+			resp.Body = &eofTracker{ReadCloser: resp.Body, closed: &closed, undrained: &undrained}
+		}
+		return resp, err
+	})}
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL), turso.WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Groups.List(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Groups.Delete(context.TODO(), "missing"); !errors.Is(err, turso.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	if closed.Load() != 2 || undrained.Load() != 0 {
+		t.Fatalf("expected 2 drained bodies, got %d closed and %d undrained", closed.Load(), undrained.Load())
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,272 @@
+package turso
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Requests are retried
+// on errors returned by the transport, 429 Too Many Requests and 5xx
+// responses. Errors raised before a request is sent, such as a failing
+// TokenSource, are returned straight away. Only idempotent
+// methods (GET, HEAD, OPTIONS, PUT and DELETE) are retried unless RetryPOST is
+// set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. It doubles with every
+	// following attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts. A response asking to
+	// retry after a longer delay with the Retry-After header is not retried.
+	MaxBackoff time.Duration
+	// RetryPOST enables retries for POST requests.
+	RetryPOST bool
+}
+
+// DefaultRetryPolicy returns a policy suitable for most workloads.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  250 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+	}
+}
+
+type withRetryPolicy struct {
+	policy RetryPolicy
+}
+
+// WithRetryPolicy enables automatic retries for every request sent by the client.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return &withRetryPolicy{policy: policy}
+}
+
+func (o *withRetryPolicy) apply(client *Client) {
+	client.retryPolicy = o.policy
+}
+
+func (p RetryPolicy) attempts(method string) int {
+	if p.MaxAttempts < 2 {
+		return 1
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return p.MaxAttempts
+	case http.MethodPost:
+		if p.RetryPOST {
+			return p.MaxAttempts
+		}
+	}
+	return 1
+}
+
+// shouldRetry reports whether an attempt is worth retrying. sent reports
+// whether err was returned by the transport rather than while building the
+// request.
+func (p RetryPolicy) shouldRetry(ctx context.Context, resp *http.Response, sent bool, err error) bool {
+	if err != nil {
+		return sent && ctx.Err() == nil
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented
+}
+
+// backoff returns the delay before the given retry, preferring the server's
+// Retry-After header when present. It reports false when Retry-After asks for
+// a longer delay than MaxBackoff.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait, p.MaxBackoff <= 0 || wait <= p.MaxBackoff
+		}
+	}
+	return p.delay(attempt), true
+}
+
+// delay returns the exponential backoff before the given retry.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	wait := p.MinBackoff
+	if wait <= 0 {
+		wait = 100 * time.Millisecond
+	}
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	// Equal jitter: wait somewhere between half and the full backoff.
+	return wait/2 + rand.N(wait/2+1)
+}
+
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// requestBody produces the body of a request for every attempt.
+type requestBody struct {
+	open        func() (io.Reader, error)
+	contentType string
+	// contentLength is the length of the body, or 0 when it is unknown.
+	contentLength int64
+	// replayable reports whether open can be called more than once.
+	replayable bool
+}
+
+// newRequestBody buffers body when the request may be sent again, i.e. when
+// the retry policy retries method or when a rejected token can be refreshed.
+func (c *Client) newRequestBody(method string, body io.Reader) (*requestBody, error) {
+	if body == nil {
+		return nil, nil
+	}
+	_, refreshable := c.tokenSource.(TokenInvalidator)
+	if c.retryPolicy.attempts(method) == 1 && !refreshable {
+		return &requestBody{open: func() (io.Reader, error) { return body, nil }}, nil
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return &requestBody{
+		open:       func() (io.Reader, error) { return bytes.NewReader(data), nil },
+		replayable: true,
+	}, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body *requestBody) (*http.Response, error) {
+	ctx, span := c.startSpan(ctx, method, path, 0)
+	ctx, stats := c.withOperationStats(ctx)
+	start := time.Now()
+	resp, attempts, err := c.send(ctx, method, path, body)
+	c.wrapResponseBody(resp)
+	c.logOperation(ctx, method, path, attempts, time.Since(start), resp, err)
+	span.End(resp, err)
+	if stats != nil {
+		c.recordMetrics(ctx, method, start, attempts, stats, resp, err)
+	}
+	return resp, err
+}
+
+// send performs the attempts of a request and returns the last response along
+// with the number of attempts made.
+func (c *Client) send(ctx context.Context, method, path string, body *requestBody) (*http.Response, int, error) {
+	attempts := c.retryPolicy.attempts(method)
+	if body != nil && !body.replayable {
+		attempts = 1
+	}
+
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.wait(ctx, path); err != nil {
+			return nil, attempt, err
+		}
+		attemptCtx, span := c.startSpan(context.WithValue(ctx, attemptKey{}, attempt), method, path, attempt)
+		attemptStart := time.Now()
+		resp, sent, err := c.attempt(attemptCtx, method, path, body)
+		span.End(resp, err)
+
+		// A rejected token is refreshed and the request retried once, on top of
+		// the attempts allowed by the retry policy.
+		if !refreshed && resp != nil && resp.StatusCode == http.StatusUnauthorized && (body == nil || body.replayable) {
+			if invalidator, ok := c.tokenSource.(TokenInvalidator); ok {
+				refreshed = true
+				rejecter, ok := c.tokenSource.(TokenRejecter)
+				var token string
+				var bearer bool
+				if resp.Request != nil {
+					token, bearer = strings.CutPrefix(resp.Request.Header.Get("Authorization"), "Bearer ")
+				}
+				if ok && bearer {
+					rejecter.Reject(token)
+				} else {
+					invalidator.Invalidate()
+				}
+				drainBody(resp.Body)
+				attempts++
+				continue
+			}
+		}
+		if attempt >= attempts || !c.retryPolicy.shouldRetry(ctx, resp, sent, err) {
+			return resp, attempt, err
+		}
+
+		wait, ok := c.retryPolicy.backoff(attempt, resp)
+		if !ok {
+			return resp, attempt, err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return resp, attempt, err
+		}
+		c.logRetry(ctx, method, path, attempt, time.Since(attemptStart), wait, resp, err)
+		if resp != nil {
+			drainBody(resp.Body)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, attempt, err
+		}
+	}
+}
+
+// attempt sends a single request. sent reports whether the request reached
+// the transport, i.e. whether err is a transport error.
+func (c *Client) attempt(ctx context.Context, method, path string, body *requestBody) (resp *http.Response, sent bool, err error) {
+	var reader io.Reader
+	if body != nil {
+		if reader, err = body.open(); err != nil {
+			return nil, false, err
+		}
+	}
+	req, err := c.NewRequest(ctx, method, path, reader)
+	if err != nil {
+		return nil, false, err
+	}
+	if body != nil && body.contentType != "" {
+		req.Header.Set("Content-Type", body.contentType)
+	}
+	if body != nil && body.contentLength > 0 {
+		req.ContentLength = body.contentLength
+	}
+	if c.tracer != nil {
+		c.tracer.Inject(ctx, req.Header)
+	}
+	countRequestBody(req)
+	resp, err = c.transport.Do(req)
+	return resp, true, err
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// drainBody reads what is left of a response body so the underlying
+// connection can be reused, then closes it.
+func drainBody(body io.ReadCloser) {
+	io.Copy(io.Discard, io.LimitReader(body, maxErrorBodySize))
+	body.Close()
+}
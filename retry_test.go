@@ -0,0 +1,168 @@
+package turso_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+)
+
+var testRetryPolicy = turso.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func Test_Retry_RetriesIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"groups":[{"name":"default"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL), turso.WithRetryPolicy(testRetryPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groups, err := client.Groups.List(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || calls.Load() != 2 {
+		t.Fatalf("expected one group after two calls, got %d groups after %d calls", len(groups), calls.Load())
+	}
+}
+
+func Test_Retry_StopsWhenRetryAfterExceedsMaxBackoff(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL), turso.WithRetryPolicy(testRetryPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Groups.List(context.TODO())
+	var apiErr *turso.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected service unavailable APIError, got: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single attempt, got %d", calls.Load())
+	}
+}
+
+// failingTokenSource counts its calls and always fails.
+type failingTokenSource struct {
+	calls atomic.Int32
+}
+
+func (s *failingTokenSource) Token(ctx context.Context) (string, error) {
+	s.calls.Add(1)
+	return "", errors.New("token file is not readable")
+}
+
+func Test_Retry_DoesNotRetryLocalErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request to be sent")
+	}))
+	defer server.Close()
+
+	source := &failingTokenSource{}
+	client, err := turso.New("", "my-org", turso.WithBaseUrl(server.URL), turso.WithTokenSource(source), turso.WithRetryPolicy(testRetryPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Groups.List(context.TODO()); err == nil {
+		t.Fatal("expected the token source error to be returned")
+	}
+	if source.calls.Load() != 1 {
+		t.Fatalf("expected a single attempt, got %d", source.calls.Load())
+	}
+}
+
+func Test_Retry_DoesNotRetryPostByDefault(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL), turso.WithRetryPolicy(testRetryPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.Groups.Create(context.TODO(), "default", "ams", "latest")
+	var apiErr *turso.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected bad gateway APIError, got: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single attempt, got %d", calls.Load())
+	}
+}
+
+func Test_Retry_ReplaysRequestBodies(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("failed to read form file: %s", err)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		if string(data) != "dump contents" {
+			t.Errorf("unexpected upload contents: %q", data)
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"dump_url":"https://example.com/dump"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "dump.sql")
+	if err := os.WriteFile(path, []byte("dump contents"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	policy := testRetryPolicy
+	policy.RetryPOST = true
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL), turso.WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	url, err := client.Databases.UploadDump(context.TODO(), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(url, "/dump") || calls.Load() != 2 {
+		t.Fatalf("expected dump url after two calls, got %q after %d calls", url, calls.Load())
+	}
+}
@@ -0,0 +1,149 @@
+package turso
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Stats are the query statistics of a database. Statistics the SDK does not
+// model yet are kept in Extra.
+type Stats struct {
+	// TopQueries are the queries that read and wrote the most rows.
+	TopQueries []TopQuery `json:"top_queries,omitempty"`
+	// SlowestQueries are the queries that took the longest to run.
+	SlowestQueries []SlowQuery `json:"slowest_queries,omitempty"`
+
+	// Extra holds fields returned by the API that are not modeled above.
+	Extra Extra `json:"-"`
+}
+
+func (s *Stats) UnmarshalJSON(data []byte) error {
+	type stats Stats
+	extra, err := unmarshalExtra(data, (*stats)(s))
+	s.Extra = extra
+	return err
+}
+
+func (s Stats) MarshalJSON() ([]byte, error) {
+	type stats Stats
+	return marshalExtra(stats(s), s.Extra)
+}
+
+// TopQuery describes a query listed in Stats.TopQueries. It is an alias of
+// the type TopQueries always had, so existing code using it keeps compiling.
+type TopQuery = struct {
+	Query       string `json:"query"`
+	RowsRead    int    `json:"rows_read"`
+	RowsWritten int    `json:"rows_written"`
+}
+
+// SlowQuery describes a query listed in Stats.SlowestQueries.
+type SlowQuery struct {
+	Query       string `json:"query"`
+	RowsRead    int    `json:"rows_read"`
+	RowsWritten int    `json:"rows_written"`
+	// ElapsedMs is how long the query took in milliseconds.
+	ElapsedMs uint64 `json:"elapsed_ms"`
+}
+
+// MergeStats merges the stats of several instances of a database. Top
+// queries listed by several instances have their rows summed and are sorted
+// by rows read and written. Slowest queries keep their slowest run and are
+// sorted by elapsed time. Extra fields are dropped.
+func MergeStats(stats ...Stats) Stats {
+	var merged Stats
+	top := map[string]*TopQuery{}
+	slowest := map[string]*SlowQuery{}
+	for _, s := range stats {
+		for _, q := range s.TopQueries {
+			if m, ok := top[q.Query]; ok {
+				m.RowsRead += q.RowsRead
+				m.RowsWritten += q.RowsWritten
+			} else {
+				top[q.Query] = &q
+			}
+		}
+		for _, q := range s.SlowestQueries {
+			if m, ok := slowest[q.Query]; !ok || q.ElapsedMs > m.ElapsedMs {
+				slowest[q.Query] = &q
+			}
+		}
+	}
+
+	merged.TopQueries = sortedQueries(top, func(a, b *TopQuery) bool {
+		return a.RowsRead+a.RowsWritten > b.RowsRead+b.RowsWritten
+	})
+	merged.SlowestQueries = sortedQueries(slowest, func(a, b *SlowQuery) bool {
+		return a.ElapsedMs > b.ElapsedMs
+	})
+	return merged
+}
+
+// sortedQueries returns the values of queries sorted with less, and then by
+// query text.
+func sortedQueries[T any](queries map[string]*T, less func(a, b *T) bool) []T {
+	if len(queries) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(queries))
+	for key := range queries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := queries[keys[i]], queries[keys[j]]
+		if less(a, b) {
+			return true
+		}
+		if less(b, a) {
+			return false
+		}
+		return keys[i] < keys[j]
+	})
+	sorted := make([]T, len(keys))
+	for i, key := range keys {
+		sorted[i] = *queries[key]
+	}
+	return sorted
+}
+
+// Add returns the sum of u and o.
+func (u Usage) Add(o Usage) Usage {
+	return Usage{
+		RowsRead:         u.RowsRead + o.RowsRead,
+		RowsWritten:      u.RowsWritten + o.RowsWritten,
+		StorageBytesUsed: u.StorageBytesUsed + o.StorageBytesUsed,
+		BytesSynced:      u.BytesSynced + o.BytesSynced,
+	}
+}
+
+// ByLocation sums the usage of the instances of u by location, using
+// instances as returned by InstancesClient.List to find the location of each
+// instance. Usage of instances missing from instances, e.g. deleted since, is
+// keyed by an empty location.
+func (u DbUsage) ByLocation(instances []Instance) map[string]Usage {
+	regions := make(map[string]string, len(instances))
+	for _, instance := range instances {
+		regions[instance.Uuid] = instance.Region
+	}
+	locations := map[string]Usage{}
+	for _, instance := range u.Instances {
+		region := regions[instance.UUID]
+		locations[region] = locations[region].Add(instance.Usage)
+	}
+	return locations
+}
+
+// UsageByLocation returns the usage of a database between from and to summed
+// by location, see UsageBetween and DbUsage.ByLocation.
+func (c *DatabasesClient) UsageByLocation(ctx context.Context, database string, from, to time.Time) (map[string]Usage, error) {
+	usage, err := c.UsageBetween(ctx, database, from, to)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := c.client.Instances.List(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+	return usage.ByLocation(instances), nil
+}
@@ -0,0 +1,109 @@
+package turso_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/tursotest"
+)
+
+func Test_Stats_Decode(t *testing.T) {
+	data := []byte(`{
+		"top_queries": [{"query": "SELECT * FROM users", "rows_read": 100, "rows_written": 0}],
+		"slowest_queries": [{"query": "SELECT * FROM logs", "rows_read": 5000, "rows_written": 0, "elapsed_ms": 812}],
+		"replication_index": 7
+	}`)
+
+	var stats turso.Stats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.TopQueries[0].RowsRead != 100 || stats.SlowestQueries[0].ElapsedMs != 812 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if _, ok := stats.Extra["replication_index"]; !ok {
+		t.Fatalf("expected unmodeled fields to be kept, got: %v", stats.Extra)
+	}
+
+	// TopQueries keeps the type it always had.
+	var top []struct {
+		Query       string `json:"query"`
+		RowsRead    int    `json:"rows_read"`
+		RowsWritten int    `json:"rows_written"`
+	} = stats.TopQueries
+	if top[0].Query != "SELECT * FROM users" {
+		t.Fatalf("unexpected top queries: %+v", top)
+	}
+}
+
+func Test_MergeStats(t *testing.T) {
+	primary := turso.Stats{
+		TopQueries:     []turso.TopQuery{{Query: "a", RowsRead: 10}, {Query: "b", RowsRead: 5}},
+		SlowestQueries: []turso.SlowQuery{{Query: "a", ElapsedMs: 20}},
+	}
+	replica := turso.Stats{
+		TopQueries:     []turso.TopQuery{{Query: "b", RowsRead: 20, RowsWritten: 1}},
+		SlowestQueries: []turso.SlowQuery{{Query: "a", ElapsedMs: 50}, {Query: "c", ElapsedMs: 30}},
+	}
+
+	merged := turso.MergeStats(primary, replica)
+	if len(merged.TopQueries) != 2 || merged.TopQueries[0].Query != "b" || merged.TopQueries[0].RowsRead != 25 {
+		t.Fatalf("expected top queries to be merged and sorted, got: %+v", merged.TopQueries)
+	}
+	if len(merged.SlowestQueries) != 2 || merged.SlowestQueries[0].Query != "a" || merged.SlowestQueries[0].ElapsedMs != 50 {
+		t.Fatalf("expected slowest queries to be merged and sorted, got: %+v", merged.SlowestQueries)
+	}
+	if primary.TopQueries[1].RowsRead != 5 {
+		t.Fatal("expected the merged stats not to be modified")
+	}
+}
+
+func Test_Databases_UsageByLocation(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+	ctx := context.TODO()
+
+	if err := client.Groups.Create(ctx, "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Groups.AddLocation(ctx, "default", "fra"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.Create(ctx, "my-db", "", "", "", "default", "", false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.SetUsage(tursotest.DefaultOrg, "my-db", turso.Usage{RowsRead: 10, RowsWritten: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	to := time.Now()
+	from := to.Add(-24 * time.Hour)
+	locations, err := client.Databases.UsageByLocation(ctx, "my-db", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations) != 2 || locations["ams"].RowsRead != 10 || locations["fra"].RowsRead != 0 {
+		t.Fatalf("expected usage by location, got: %+v", locations)
+	}
+
+	requests := server.Requests()
+	var query string
+	for _, request := range requests {
+		if strings.HasSuffix(request.Path, "/usage") {
+			query = request.Query
+		}
+	}
+	if !strings.Contains(query, "from=") || !strings.Contains(query, "to=") {
+		t.Fatalf("expected the range to be sent, got query %q", query)
+	}
+
+	if _, err := client.Databases.UsageBetween(ctx, "my-db", to, from); !errors.Is(err, turso.ErrInvalidOptions) {
+		t.Fatalf("expected ErrInvalidOptions, got: %v", err)
+	}
+}
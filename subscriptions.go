@@ -2,9 +2,7 @@ package turso
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"net/http"
 )
 
 type SubscriptionClient client
@@ -13,13 +11,26 @@ type Subscription struct {
 	Plan     string `json:"plan"`
 	Timeline string `json:"timeline"`
 	Overages bool   `json:"overages"`
+
+	// Extra holds fields returned by the API that are not modeled above.
+	Extra Extra `json:"-"`
+}
+
+func (s *Subscription) UnmarshalJSON(data []byte) error {
+	type subscription Subscription
+	extra, err := unmarshalExtra(data, (*subscription)(s))
+	s.Extra = extra
+	return err
+}
+
+func (s Subscription) MarshalJSON() ([]byte, error) {
+	type subscription Subscription
+	return marshalExtra(subscription(s), s.Extra)
 }
 
 func (c *SubscriptionClient) Get(ctx context.Context) (Subscription, error) {
-	prefix := "/v1"
-	if c.client.Org != "" {
-		prefix = "/v1/organizations/" + c.client.Org
-	}
+	ctx = WithOperation(ctx, "subscriptions.get")
+	prefix := c.client.orgPrefix(ctx)
 
 	r, err := c.client.Get(ctx, prefix+"/subscription", nil)
 	if err != nil {
@@ -28,20 +39,16 @@ func (c *SubscriptionClient) Get(ctx context.Context) (Subscription, error) {
 	defer r.Body.Close()
 
 	if r.StatusCode != 200 {
-		return Subscription{}, fmt.Errorf("failed to get organization plan with status %s: %v", r.Status, parseResponseError(r))
+		return Subscription{}, fmt.Errorf("failed to get organization plan: %w", parseResponseError(r))
 	}
 
 	resp, err := unmarshal[struct{ Subscription Subscription }](r)
 	return resp.Subscription, err
 }
 
-var ErrPaymentRequired = errors.New("payment required")
-
 func (c *SubscriptionClient) Update(ctx context.Context, plan, timeline string, overages *bool) error {
-	prefix := "/v1"
-	if c.client.Org != "" {
-		prefix = "/v1/organizations/" + c.client.Org
-	}
+	ctx = WithOperation(ctx, "subscriptions.update")
+	prefix := c.client.orgPrefix(ctx)
 
 	body, err := marshal(struct {
 		Plan     string `json:"plan"`
@@ -58,12 +65,8 @@ func (c *SubscriptionClient) Update(ctx context.Context, plan, timeline string,
 	}
 	defer r.Body.Close()
 
-	if r.StatusCode == http.StatusPaymentRequired {
-		return ErrPaymentRequired
-	}
-
 	if r.StatusCode != 200 {
-		return fmt.Errorf("failed to set organization plan with status %s: %v", r.Status, parseResponseError(r))
+		return fmt.Errorf("failed to set organization plan: %w", parseResponseError(r))
 	}
 
 	return nil
@@ -9,9 +9,10 @@ import (
 type TokensClient client
 
 func (c *TokensClient) Validate(ctx context.Context, token string) (int64, error) {
+	ctx = WithOperation(ctx, "tokens.validate")
 	r, err := c.client.Get(ctx, "/v1/auth/validate", nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to request validation: %s", err)
+		return 0, fmt.Errorf("failed to request validation: %w", err)
 	}
 	defer r.Body.Close()
 
@@ -28,9 +29,10 @@ func (c *TokensClient) Validate(ctx context.Context, token string) (int64, error
 }
 
 func (c *TokensClient) Invalidate(ctx context.Context) (int64, error) {
+	ctx = WithOperation(ctx, "tokens.invalidate")
 	r, err := c.client.Post(ctx, "/v1/auth/invalidate", nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to request invalidation: %s", err)
+		return 0, fmt.Errorf("failed to request invalidation: %w", err)
 	}
 	defer r.Body.Close()
 
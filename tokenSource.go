@@ -0,0 +1,207 @@
+package turso
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// TokenSource supplies the Platform API token used to authenticate requests.
+// It is called for every attempt and must be safe for concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenInvalidator is implemented by token sources that cache their token.
+// When a request is rejected with 401 Unauthorized the client calls
+// Invalidate and retries the request once with a fresh token.
+type TokenInvalidator interface {
+	Invalidate()
+}
+
+// TokenRejecter is implemented by token invalidators that can discard a
+// specific token. The client calls Reject with the rejected token instead of
+// Invalidate, so a token fetched since it was sent, e.g. by a request of
+// another view of the client, is kept.
+type TokenRejecter interface {
+	Reject(token string)
+}
+
+type withTokenSource struct {
+	source TokenSource
+}
+
+// WithTokenSource authenticates requests with tokens from source instead of
+// the static token passed to New.
+func WithTokenSource(source TokenSource) ClientOption {
+	return &withTokenSource{source: source}
+}
+
+func (o *withTokenSource) apply(client *Client) {
+	client.tokenSource = o.source
+}
+
+type staticTokenSource string
+
+// StaticTokenSource returns a TokenSource that always returns token.
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource(token)
+}
+
+func (s staticTokenSource) Token(context.Context) (string, error) {
+	if s == "" {
+		return "", ErrMissingAPIToken
+	}
+	return string(s), nil
+}
+
+type envTokenSource string
+
+// EnvTokenSource returns a TokenSource that reads the token from the
+// environment variable name on every call.
+func EnvTokenSource(name string) TokenSource {
+	return envTokenSource(name)
+}
+
+func (s envTokenSource) Token(context.Context) (string, error) {
+	token := os.Getenv(string(s))
+	if token == "" {
+		return "", fmt.Errorf("%w: environment variable %s is empty", ErrMissingAPIToken, string(s))
+	}
+	return token, nil
+}
+
+// FileTokenSource returns a TokenSource that reads the token from the file at
+// path. The file is read again whenever its size or modification time
+// changes, so the token can be rotated in place. Surrounding whitespace is
+// ignored.
+func FileTokenSource(path string) TokenSource {
+	return &fileTokenSource{path: path}
+}
+
+type fileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	size    int64
+	modTime time.Time
+}
+
+func (s *fileTokenSource) Token(context.Context) (string, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && info.Size() == s.size && info.ModTime().Equal(s.modTime) {
+		return s.token, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := string(bytes.TrimSpace(data))
+	if token == "" {
+		return "", fmt.Errorf("%w: token file %s is empty", ErrMissingAPIToken, s.path)
+	}
+
+	s.token, s.size, s.modTime = token, info.Size(), info.ModTime()
+	return s.token, nil
+}
+
+// CachingTokenSource caches the token of another TokenSource until it is
+// rejected, which the client does when a request is rejected with 401
+// Unauthorized. Views of a client created with ForOrg share its token source,
+// so they share the cached token too.
+type CachingTokenSource struct {
+	source TokenSource
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewCachingTokenSource wraps source in a CachingTokenSource.
+func NewCachingTokenSource(source TokenSource) *CachingTokenSource {
+	return &CachingTokenSource{source: source}
+}
+
+func (s *CachingTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" {
+		return s.token, nil
+	}
+	token, err := s.source.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	return token, nil
+}
+
+// Invalidate discards the cached token so the next call to Token fetches a
+// new one.
+func (s *CachingTokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = ""
+}
+
+// Reject discards the cached token if it is token, so the next call to Token
+// fetches a new one. A token that was fetched since token is kept.
+func (s *CachingTokenSource) Reject(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// maxSecrets is the number of tokens a client remembers for redaction. Older
+// tokens are forgotten, so a client using a refreshing TokenSource does not
+// grow without bound.
+const maxSecrets = 8
+
+// secrets remembers the most recent tokens sent by a client so they can be
+// redacted.
+type secrets struct {
+	mu     sync.RWMutex
+	values []string
+}
+
+func (s *secrets) add(value string) {
+	s.mu.RLock()
+	ok := slices.Contains(s.values, value)
+	s.mu.RUnlock()
+	if ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if slices.Contains(s.values, value) {
+		return
+	}
+	if len(s.values) == maxSecrets {
+		s.values = slices.Delete(s.values, 0, 1)
+	}
+	s.values = append(s.values, value)
+}
+
+func (s *secrets) each(fn func(value string)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, value := range s.values {
+		fn(value)
+	}
+}
@@ -0,0 +1,124 @@
+package turso_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+)
+
+type rotatingTokenSource struct {
+	calls atomic.Int32
+}
+
+func (s *rotatingTokenSource) Token(context.Context) (string, error) {
+	if s.calls.Add(1) == 1 {
+		return "expired-token", nil
+	}
+	return "fresh-token", nil
+}
+
+func Test_TokenSource_RefreshesOnUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"databases":[]}`))
+	}))
+	defer server.Close()
+
+	source := &rotatingTokenSource{}
+	client, err := turso.New("", "my-org", turso.WithBaseUrl(server.URL), turso.WithTokenSource(turso.NewCachingTokenSource(source)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Databases.List(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.List(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	if source.calls.Load() != 2 {
+		t.Fatalf("expected the token to be fetched twice, got %d", source.calls.Load())
+	}
+}
+
+func Test_TokenSource_RefreshesOnUnauthorizedWithBody(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("Authorization") != "Bearer fresh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if body, _ := io.ReadAll(r.Body); !strings.Contains(string(body), `"default"`) {
+			http.Error(w, "missing body", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"group":{}}`))
+	}))
+	defer server.Close()
+
+	client, err := turso.New("", "my-org", turso.WithBaseUrl(server.URL), turso.WithTokenSource(turso.NewCachingTokenSource(&rotatingTokenSource{})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Groups.Create(context.TODO(), "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected the request to be sent again with its body, got %d requests", calls.Load())
+	}
+}
+
+func Test_CachingTokenSource_RejectKeepsNewerToken(t *testing.T) {
+	source := turso.NewCachingTokenSource(&rotatingTokenSource{})
+	if token, err := source.Token(context.TODO()); err != nil || token != "expired-token" {
+		t.Fatalf("expected expired-token, got %q (%v)", token, err)
+	}
+	source.Reject("expired-token")
+	if token, err := source.Token(context.TODO()); err != nil || token != "fresh-token" {
+		t.Fatalf("expected fresh-token, got %q (%v)", token, err)
+	}
+
+	// A 401 for a request that was sent with the old token must not drop the
+	// token fetched since.
+	source.Reject("expired-token")
+	if token, err := source.Token(context.TODO()); err != nil || token != "fresh-token" {
+		t.Fatalf("expected fresh-token to be kept, got %q (%v)", token, err)
+	}
+}
+
+func Test_FileTokenSource_ReadsRotatedToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	source := turso.FileTokenSource(path)
+	if token, err := source.Token(context.TODO()); err != nil || token != "first" {
+		t.Fatalf("expected first token, got %q (%v)", token, err)
+	}
+
+	if err := os.WriteFile(path, []byte("second-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := source.Token(context.TODO()); err != nil || token != "second-token" {
+		t.Fatalf("expected rotated token, got %q (%v)", token, err)
+	}
+}
@@ -0,0 +1,67 @@
+package turso
+
+import (
+	"context"
+	"net/http"
+)
+
+// SpanInfo describes the operation, or the attempt of an operation, a span is
+// started for.
+type SpanInfo struct {
+	// Operation is the logical operation name, e.g. "databases.create".
+	Operation string
+	Method    string
+	// Path is the request path without its query string.
+	Path string
+	Org  string
+	// Attempt is 0 for the span covering a whole operation, and the attempt
+	// number starting at 1 for the spans covering each attempt.
+	Attempt int
+}
+
+// Tracer starts spans around operations and their attempts. Attempt spans are
+// started with the context returned for their operation span, so they can be
+// recorded as its children. The otel subpackage provides an OpenTelemetry
+// implementation.
+type Tracer interface {
+	Start(ctx context.Context, info SpanInfo) (context.Context, Span)
+	// Inject writes the trace context carried by ctx into the headers of an
+	// outgoing request.
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span is ended with the final response or error of the operation or attempt
+// it covers. The response body must not be read.
+type Span interface {
+	End(resp *http.Response, err error)
+}
+
+type withTracer struct {
+	tracer Tracer
+}
+
+// WithTracer traces every operation sent by the client with tracer.
+func WithTracer(tracer Tracer) ClientOption {
+	return &withTracer{tracer: tracer}
+}
+
+func (o *withTracer) apply(client *Client) {
+	client.tracer = o.tracer
+}
+
+type noopSpan struct{}
+
+func (noopSpan) End(*http.Response, error) {}
+
+func (c *Client) startSpan(ctx context.Context, method, path string, attempt int) (context.Context, Span) {
+	if c.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return c.tracer.Start(ctx, SpanInfo{
+		Operation: OperationFromContext(ctx),
+		Method:    method,
+		Path:      logPath(path),
+		Org:       c.org(ctx),
+		Attempt:   attempt,
+	})
+}
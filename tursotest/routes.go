@@ -0,0 +1,913 @@
+package tursotest
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/alehechka/turso-go"
+)
+
+// orgHandler handles a request scoped to an existing organization. It is
+// called with the server's lock held.
+type orgHandler func(w http.ResponseWriter, r *http.Request, org *organization)
+
+func (s *Server) routes(mux *http.ServeMux) {
+	const org = "/v1/organizations/{org}"
+
+	mux.HandleFunc("GET /v1/locations", s.listLocations)
+	mux.HandleFunc("GET /v1/locations/{location}", s.getLocation)
+	mux.HandleFunc("GET /v1/current-user", s.currentUser)
+	mux.HandleFunc("GET /v1/auth/validate", s.validateToken)
+	mux.HandleFunc("GET /v1/auth/api-tokens", s.listAPITokens)
+	mux.HandleFunc("POST /v2/auth/api-tokens/{name}", s.createAPIToken)
+	mux.HandleFunc("DELETE /v1/auth/api-tokens/{name}", s.revokeAPIToken)
+
+	mux.HandleFunc("GET /v1/plans", s.listPlans)
+
+	mux.HandleFunc("GET /v2/organizations", s.listOrganizations)
+	mux.HandleFunc("POST /v1/organizations", s.createOrganization)
+	mux.HandleFunc("DELETE "+org, s.deleteOrganization)
+	mux.HandleFunc("PATCH "+org, s.withOrg(s.updateOrganization))
+	mux.HandleFunc("GET "+org+"/usage", s.withOrg(s.organizationUsage))
+	mux.HandleFunc("GET "+org+"/subscription", s.withOrg(s.getSubscription))
+	mux.HandleFunc("POST "+org+"/subscription", s.withOrg(s.updateSubscription))
+	mux.HandleFunc("GET "+org+"/members", s.withOrg(s.listMembers))
+	mux.HandleFunc("POST "+org+"/members", s.withOrg(s.addMember))
+	mux.HandleFunc("DELETE "+org+"/members/{username}", s.withOrg(s.removeMember))
+	mux.HandleFunc("GET "+org+"/invites", s.withOrg(s.listInvites))
+	mux.HandleFunc("POST "+org+"/invite", s.withOrg(s.inviteMember))
+	mux.HandleFunc("DELETE "+org+"/invites/{email}", s.withOrg(s.deleteInvite))
+
+	mux.HandleFunc("GET "+org+"/groups", s.withOrg(s.listGroups))
+	mux.HandleFunc("POST "+org+"/groups", s.withOrg(s.createGroup))
+	mux.HandleFunc("GET "+org+"/groups/{group}", s.withOrg(s.getGroup))
+	mux.HandleFunc("DELETE "+org+"/groups/{group}", s.withOrg(s.deleteGroup))
+	mux.HandleFunc("POST "+org+"/groups/{group}/unarchive", s.withOrg(s.unarchiveGroup))
+	mux.HandleFunc("POST "+org+"/groups/{group}/locations/{location}", s.withOrg(s.addGroupLocation))
+	mux.HandleFunc("DELETE "+org+"/groups/{group}/locations/{location}", s.withOrg(s.removeGroupLocation))
+	mux.HandleFunc("GET "+org+"/groups/{group}/locations/{location}/wait", s.withOrg(s.waitGroupLocation))
+	mux.HandleFunc("POST "+org+"/groups/{group}/auth/tokens", s.withOrg(s.groupToken))
+	mux.HandleFunc("POST "+org+"/groups/{group}/auth/rotate", s.withOrg(s.rotateGroup))
+	mux.HandleFunc("POST "+org+"/groups/{group}/update", s.withOrg(s.updateGroup))
+	mux.HandleFunc("POST "+org+"/groups/{group}/transfer", s.withOrg(s.transferGroup))
+
+	mux.HandleFunc("GET "+org+"/databases", s.withOrg(s.listDatabases))
+	mux.HandleFunc("POST "+org+"/databases", s.withOrg(s.createDatabase))
+	mux.HandleFunc("POST "+org+"/databases/dumps", s.withOrg(s.uploadDump))
+	mux.HandleFunc("GET "+org+"/databases/{database}", s.withOrg(s.getDatabase))
+	mux.HandleFunc("DELETE "+org+"/databases/{database}", s.withOrg(s.deleteDatabase))
+	mux.HandleFunc("POST "+org+"/databases/{database}/seed", s.withOrg(s.seedDatabase))
+	mux.HandleFunc("POST "+org+"/databases/{database}/auth/tokens", s.withOrg(s.databaseToken))
+	mux.HandleFunc("POST "+org+"/databases/{database}/auth/rotate", s.withOrg(s.rotateDatabase))
+	mux.HandleFunc("POST "+org+"/databases/{database}/update", s.withOrg(s.updateDatabase))
+	mux.HandleFunc("GET "+org+"/databases/{database}/stats", s.withOrg(s.databaseStats))
+	mux.HandleFunc("POST "+org+"/databases/{database}/transfer", s.withOrg(s.transferDatabase))
+	mux.HandleFunc("POST "+org+"/databases/{database}/wakeup", s.withOrg(s.wakeupDatabase))
+	mux.HandleFunc("GET "+org+"/databases/{database}/usage", s.withOrg(s.databaseUsage))
+	mux.HandleFunc("GET "+org+"/databases/{database}/configuration", s.withOrg(s.getDatabaseConfig))
+	mux.HandleFunc("PATCH "+org+"/databases/{database}/configuration", s.withOrg(s.updateDatabaseConfig))
+
+	mux.HandleFunc("GET "+org+"/databases/{database}/instances", s.withOrg(s.listInstances))
+	mux.HandleFunc("POST "+org+"/databases/{database}/instances", s.withOrg(s.createInstance))
+	mux.HandleFunc("DELETE "+org+"/databases/{database}/instances/{instance}", s.withOrg(s.deleteInstance))
+	mux.HandleFunc("GET "+org+"/databases/{database}/instances/{instance}/wait", s.withOrg(s.waitInstance))
+}
+
+// withOrg resolves the organization of the request, rejecting organizations
+// the current user is not a member of like the real API does.
+func (s *Server) withOrg(handler orgHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		org, ok := s.orgs[r.PathValue("org")]
+		if !ok {
+			writeError(w, http.StatusForbidden, "you are not a member of organization "+r.PathValue("org"))
+			return
+		}
+		handler(w, r, org)
+	}
+}
+
+// Locations
+
+func (s *Server) listLocations(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"locations": s.locations})
+}
+
+func (s *Server) getLocation(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("location")
+	description, ok := s.locations[code]
+	if !ok {
+		writeError(w, http.StatusNotFound, "location "+code+" not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"location": map[string]string{"code": code, "description": description},
+	})
+}
+
+func (s *Server) validLocation(code string) bool {
+	_, ok := s.locations[code]
+	return ok
+}
+
+// Users and API tokens
+
+func (s *Server) currentUser(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"user": turso.UserInfo{Username: s.user, Plan: "starter"},
+	})
+}
+
+func (s *Server) validateToken(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"exp": time.Now().Add(time.Hour).Unix()})
+}
+
+func (s *Server) listAPITokens(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens := make([]map[string]string, 0, len(s.apiTokens))
+	for _, name := range sortedKeys(s.apiTokens) {
+		tokens = append(tokens, map[string]string{"name": name, "dbId": s.apiTokens[name].ID})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"tokens": tokens})
+}
+
+func (s *Server) createAPIToken(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := r.PathValue("name")
+	if _, ok := s.apiTokens[name]; ok {
+		writeError(w, http.StatusConflict, "a token with name "+name+" already exists")
+		return
+	}
+	token := turso.CreateApiToken{Name: name, ID: newUUID(), Value: newJWT(map[string]any{"name": name})}
+	s.apiTokens[name] = token
+	writeJSON(w, http.StatusOK, map[string]any{"token": token})
+}
+
+func (s *Server) revokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := r.PathValue("name")
+	if _, ok := s.apiTokens[name]; !ok {
+		writeError(w, http.StatusNotFound, "token "+name+" not found")
+		return
+	}
+	delete(s.apiTokens, name)
+	writeJSON(w, http.StatusOK, map[string]any{"token": name})
+}
+
+// Organizations
+
+func (s *Server) listOrganizations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orgs := make([]turso.Organization, 0, len(s.orgs))
+	for _, slug := range sortedKeys(s.orgs) {
+		orgs = append(orgs, s.orgs[slug].org)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"organizations": orgs})
+}
+
+func (s *Server) createOrganization(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var body turso.Organization
+	if err := readJSON(r, &body); err != nil || body.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid organization")
+		return
+	}
+	if _, ok := s.orgs[body.Name]; ok {
+		writeError(w, http.StatusConflict, "organization "+body.Name+" already exists")
+		return
+	}
+	org := newOrganization(body.Name)
+	org.org.StripeID = body.StripeID
+	if r.URL.Query().Get("dry_run") != "true" {
+		s.orgs[body.Name] = org
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"org": org.org})
+}
+
+func (s *Server) deleteOrganization(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	slug := r.PathValue("org")
+	if _, ok := s.orgs[slug]; !ok {
+		writeError(w, http.StatusNotFound, "organization "+slug+" not found")
+		return
+	}
+	delete(s.orgs, slug)
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) updateOrganization(w http.ResponseWriter, r *http.Request, org *organization) {
+	var body struct {
+		Overages *bool `json:"overages"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if body.Overages != nil {
+		org.org.Overages = *body.Overages
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"org": org.org})
+}
+
+func (s *Server) organizationUsage(w http.ResponseWriter, r *http.Request, org *organization) {
+	total := turso.OrgTotal{
+		Databases: uint64(len(org.databases)),
+		Groups:    uint64(len(org.groups)),
+	}
+	locations := map[string]bool{}
+	databases := make([]turso.DbUsage, 0, len(org.databases))
+	for _, name := range sortedKeys(org.databases) {
+		db := org.databases[name]
+		total.RowsRead += db.usage.RowsRead
+		total.RowsWritten += db.usage.RowsWritten
+		total.StorageBytesUsed += db.usage.StorageBytesUsed
+		total.BytesSynced += db.usage.BytesSynced
+		for _, region := range db.regions() {
+			locations[region] = true
+		}
+		databases = append(databases, db.usageResponse())
+	}
+	total.Locations = uint64(len(locations))
+
+	writeJSON(w, http.StatusOK, turso.OrgUsageResponse{OrgUsage: turso.OrgUsage{
+		UUID:      org.org.Slug,
+		Usage:     total,
+		Databases: databases,
+	}})
+}
+
+func (s *Server) listMembers(w http.ResponseWriter, r *http.Request, org *organization) {
+	writeJSON(w, http.StatusOK, map[string]any{"members": org.members})
+}
+
+func (s *Server) addMember(w http.ResponseWriter, r *http.Request, org *organization) {
+	var member turso.Member
+	if err := readJSON(r, &member); err != nil || member.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid member")
+		return
+	}
+	if slices.ContainsFunc(org.members, func(m turso.Member) bool { return m.Name == member.Name }) {
+		writeError(w, http.StatusConflict, "user "+member.Name+" is already a member")
+		return
+	}
+	org.members = append(org.members, member)
+	writeJSON(w, http.StatusOK, map[string]any{"member": member})
+}
+
+func (s *Server) removeMember(w http.ResponseWriter, r *http.Request, org *organization) {
+	username := r.PathValue("username")
+	idx := slices.IndexFunc(org.members, func(m turso.Member) bool { return m.Name == username })
+	if idx < 0 {
+		writeError(w, http.StatusNotFound, "member "+username+" not found")
+		return
+	}
+	org.members = slices.Delete(org.members, idx, idx+1)
+	writeJSON(w, http.StatusOK, map[string]any{"member": username})
+}
+
+func (s *Server) listInvites(w http.ResponseWriter, r *http.Request, org *organization) {
+	writeJSON(w, http.StatusOK, map[string]any{"invites": org.invites})
+}
+
+func (s *Server) inviteMember(w http.ResponseWriter, r *http.Request, org *organization) {
+	var invite turso.Invite
+	if err := readJSON(r, &invite); err != nil || invite.Email == "" {
+		writeError(w, http.StatusBadRequest, "invalid invite")
+		return
+	}
+	org.invites = append(org.invites, invite)
+	writeJSON(w, http.StatusOK, map[string]any{"invited": invite})
+}
+
+func (s *Server) deleteInvite(w http.ResponseWriter, r *http.Request, org *organization) {
+	email := r.PathValue("email")
+	idx := slices.IndexFunc(org.invites, func(i turso.Invite) bool { return i.Email == email })
+	if idx < 0 {
+		writeError(w, http.StatusNotFound, "invite for "+email+" not found")
+		return
+	}
+	org.invites = slices.Delete(org.invites, idx, idx+1)
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+// Plans
+
+func (s *Server) listPlans(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"plans": s.plans})
+}
+
+func (s *Server) getSubscription(w http.ResponseWriter, r *http.Request, org *organization) {
+	writeJSON(w, http.StatusOK, map[string]any{"subscription": org.subscription})
+}
+
+func (s *Server) updateSubscription(w http.ResponseWriter, r *http.Request, org *organization) {
+	var body struct {
+		Plan     string `json:"plan"`
+		Timeline string `json:"timeline"`
+		Overages *bool  `json:"overages"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if _, ok := s.plan(body.Plan); !ok {
+		writeError(w, http.StatusBadRequest, "plan "+body.Plan+" not found")
+		return
+	}
+	org.subscription.Plan = body.Plan
+	if body.Timeline != "" {
+		org.subscription.Timeline = body.Timeline
+	}
+	if body.Overages != nil {
+		org.subscription.Overages = *body.Overages
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+// Groups
+
+func (s *Server) lookupGroup(w http.ResponseWriter, r *http.Request, org *organization) (*group, bool) {
+	name := r.PathValue("group")
+	g, ok := org.groups[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "group "+name+" not found")
+	}
+	return g, ok
+}
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request, org *organization) {
+	groups := make([]map[string]any, 0, len(org.groups))
+	for _, name := range sortedKeys(org.groups) {
+		groups = append(groups, org.groups[name].response())
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"groups": groups})
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request, org *organization) {
+	var body struct{ Name, Location, Version string }
+	if err := readJSON(r, &body); err != nil || body.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid group")
+		return
+	}
+	if _, ok := org.groups[body.Name]; ok {
+		writeError(w, http.StatusConflict, "group "+body.Name+" already exists")
+		return
+	}
+	if !s.validLocation(body.Location) {
+		writeError(w, http.StatusBadRequest, "invalid location "+body.Location)
+		return
+	}
+	if body.Version == "" {
+		body.Version = "latest"
+	}
+	g := &group{
+		uuid:      newUUID(),
+		name:      body.Name,
+		version:   body.Version,
+		primary:   body.Location,
+		locations: []string{body.Location},
+	}
+	org.groups[g.name] = g
+	writeJSON(w, http.StatusOK, map[string]any{"group": g.response()})
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request, org *organization) {
+	if g, ok := s.lookupGroup(w, r, org); ok {
+		writeJSON(w, http.StatusOK, map[string]any{"group": g.response()})
+	}
+}
+
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request, org *organization) {
+	g, ok := s.lookupGroup(w, r, org)
+	if !ok {
+		return
+	}
+	for name, db := range org.databases {
+		if db.group == g.name {
+			delete(org.databases, name)
+		}
+	}
+	delete(org.groups, g.name)
+	writeJSON(w, http.StatusOK, map[string]any{"group": g.response()})
+}
+
+func (s *Server) unarchiveGroup(w http.ResponseWriter, r *http.Request, org *organization) {
+	g, ok := s.lookupGroup(w, r, org)
+	if !ok {
+		return
+	}
+	g.archived = false
+	for _, db := range org.databases {
+		if db.group == g.name {
+			db.sleeping = false
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"group": g.response()})
+}
+
+func (s *Server) addGroupLocation(w http.ResponseWriter, r *http.Request, org *organization) {
+	g, ok := s.lookupGroup(w, r, org)
+	if !ok {
+		return
+	}
+	location := r.PathValue("location")
+	if !s.validLocation(location) {
+		writeError(w, http.StatusBadRequest, "invalid location "+location)
+		return
+	}
+	if !slices.Contains(g.locations, location) {
+		g.locations = append(g.locations, location)
+		for _, db := range org.databases {
+			if db.group == g.name {
+				db.addInstance(location, "replica")
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"group": g.response()})
+}
+
+func (s *Server) removeGroupLocation(w http.ResponseWriter, r *http.Request, org *organization) {
+	g, ok := s.lookupGroup(w, r, org)
+	if !ok {
+		return
+	}
+	location := r.PathValue("location")
+	if location == g.primary {
+		writeError(w, http.StatusBadRequest, "cannot remove the primary location of group "+g.name)
+		return
+	}
+	idx := slices.Index(g.locations, location)
+	if idx < 0 {
+		writeError(w, http.StatusNotFound, "location "+location+" not found in group "+g.name)
+		return
+	}
+	g.locations = slices.Delete(g.locations, idx, idx+1)
+	for _, db := range org.databases {
+		if db.group == g.name {
+			db.removeInstance(location)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"group": g.response()})
+}
+
+func (s *Server) waitGroupLocation(w http.ResponseWriter, r *http.Request, org *organization) {
+	g, ok := s.lookupGroup(w, r, org)
+	if !ok {
+		return
+	}
+	location := r.PathValue("location")
+	if !slices.Contains(g.locations, location) {
+		writeError(w, http.StatusNotFound, "location "+location+" not found in group "+g.name)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) groupToken(w http.ResponseWriter, r *http.Request, org *organization) {
+	if g, ok := s.lookupGroup(w, r, org); ok {
+		writeJSON(w, http.StatusOK, map[string]any{"jwt": newJWT(tokenClaims(r, "gid", g.uuid))})
+	}
+}
+
+func (s *Server) rotateGroup(w http.ResponseWriter, r *http.Request, org *organization) {
+	if _, ok := s.lookupGroup(w, r, org); ok {
+		writeJSON(w, http.StatusOK, map[string]any{})
+	}
+}
+
+func (s *Server) updateGroup(w http.ResponseWriter, r *http.Request, org *organization) {
+	g, ok := s.lookupGroup(w, r, org)
+	if !ok {
+		return
+	}
+	var body struct{ Version, Extensions string }
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if body.Version != "" {
+		g.version = body.Version
+		for _, db := range org.databases {
+			if db.group == g.name {
+				db.version = body.Version
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) transferGroup(w http.ResponseWriter, r *http.Request, org *organization) {
+	g, ok := s.lookupGroup(w, r, org)
+	if !ok {
+		return
+	}
+	var body struct {
+		Organization string `json:"organization"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	target, ok := s.orgs[body.Organization]
+	if !ok {
+		writeError(w, http.StatusForbidden, "you are not a member of organization "+body.Organization)
+		return
+	}
+	if _, ok := target.groups[g.name]; ok {
+		writeError(w, http.StatusConflict, "group "+g.name+" already exists in organization "+body.Organization)
+		return
+	}
+	for name, db := range org.databases {
+		if db.group == g.name {
+			if _, ok := target.databases[name]; ok {
+				writeError(w, http.StatusConflict, "database "+name+" already exists in organization "+body.Organization)
+				return
+			}
+		}
+	}
+	for name, db := range org.databases {
+		if db.group == g.name {
+			delete(org.databases, name)
+			db.moveTo(target.org.Slug)
+			target.databases[name] = db
+		}
+	}
+	delete(org.groups, g.name)
+	target.groups[g.name] = g
+	writeJSON(w, http.StatusOK, map[string]any{"group": g.response()})
+}
+
+// Databases
+
+func (s *Server) lookupDatabase(w http.ResponseWriter, r *http.Request, org *organization) (*database, bool) {
+	name := r.PathValue("database")
+	db, ok := org.databases[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "database "+name+" not found")
+	}
+	return db, ok
+}
+
+func (s *Server) listDatabases(w http.ResponseWriter, r *http.Request, org *organization) {
+	databases := make([]map[string]any, 0, len(org.databases))
+	for _, name := range sortedKeys(org.databases) {
+		db := org.databases[name]
+		if group := r.URL.Query().Get("group"); group != "" && db.group != group {
+			continue
+		}
+		databases = append(databases, db.response())
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"databases": databases})
+}
+
+func (s *Server) createDatabase(w http.ResponseWriter, r *http.Request, org *organization) {
+	var body turso.CreateDatabaseBody
+	if err := readJSON(r, &body); err != nil || body.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid database")
+		return
+	}
+	if _, ok := org.databases[body.Name]; ok {
+		writeError(w, http.StatusConflict, "database "+body.Name+" already exists")
+		return
+	}
+	g, ok := org.groups[body.Group]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "group "+body.Group+" not found")
+		return
+	}
+	if body.Schema != "" {
+		if schema, ok := org.databases[body.Schema]; !ok || !schema.isSchema {
+			writeError(w, http.StatusBadRequest, "schema database "+body.Schema+" not found")
+			return
+		}
+	}
+
+	db := &database{
+		id:       newUUID(),
+		name:     body.Name,
+		org:      org.org.Slug,
+		group:    g.name,
+		version:  g.version,
+		schema:   body.Schema,
+		isSchema: body.IsSchema,
+		config:   turso.DatabaseConfig{SizeLimit: body.SizeLimit},
+	}
+	if seed := body.Seed; seed != nil {
+		switch seed.Type {
+		case "database":
+			source, ok := org.databases[seed.Name]
+			if !ok {
+				writeError(w, http.StatusBadRequest, "seed database "+seed.Name+" not found")
+				return
+			}
+			db.usage.StorageBytesUsed = source.usage.StorageBytesUsed
+		case "dump":
+			if seed.URL == "" {
+				writeError(w, http.StatusBadRequest, "seed dump url is required")
+				return
+			}
+		case "database_upload":
+		default:
+			writeError(w, http.StatusBadRequest, "invalid seed type "+seed.Type)
+			return
+		}
+	}
+	db.addInstance(g.primary, "primary")
+	for _, location := range g.locations {
+		if location != g.primary {
+			db.addInstance(location, "replica")
+		}
+	}
+	org.databases[db.name] = db
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"database": map[string]string{"Name": db.name, "DbId": db.id, "Hostname": db.hostname()},
+		"username": s.user,
+	})
+}
+
+func (s *Server) uploadDump(w http.ResponseWriter, r *http.Request, org *organization) {
+	if _, err := readUpload(r); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid dump file: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"dump_url": s.URL + "/dumps/" + newUUID() + ".sql"})
+}
+
+func (s *Server) getDatabase(w http.ResponseWriter, r *http.Request, org *organization) {
+	if db, ok := s.lookupDatabase(w, r, org); ok {
+		writeJSON(w, http.StatusOK, map[string]any{"database": db.response()})
+	}
+}
+
+func (s *Server) deleteDatabase(w http.ResponseWriter, r *http.Request, org *organization) {
+	db, ok := s.lookupDatabase(w, r, org)
+	if !ok {
+		return
+	}
+	if protected := db.config.DeleteProtection; protected != nil && *protected {
+		writeError(w, http.StatusBadRequest, "database "+db.name+" is delete protected")
+		return
+	}
+	delete(org.databases, db.name)
+	writeJSON(w, http.StatusOK, map[string]string{"database": db.name})
+}
+
+func (s *Server) seedDatabase(w http.ResponseWriter, r *http.Request, org *organization) {
+	db, ok := s.lookupDatabase(w, r, org)
+	if !ok {
+		return
+	}
+	size, err := readUpload(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid database file: "+err.Error())
+		return
+	}
+	db.usage.StorageBytesUsed = uint64(size)
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) databaseToken(w http.ResponseWriter, r *http.Request, org *organization) {
+	if db, ok := s.lookupDatabase(w, r, org); ok {
+		writeJSON(w, http.StatusOK, map[string]any{"jwt": newJWT(tokenClaims(r, "id", db.id))})
+	}
+}
+
+func (s *Server) rotateDatabase(w http.ResponseWriter, r *http.Request, org *organization) {
+	if _, ok := s.lookupDatabase(w, r, org); ok {
+		writeJSON(w, http.StatusOK, map[string]any{})
+	}
+}
+
+func (s *Server) updateDatabase(w http.ResponseWriter, r *http.Request, org *organization) {
+	db, ok := s.lookupDatabase(w, r, org)
+	if !ok {
+		return
+	}
+	if g, ok := org.groups[db.group]; ok {
+		db.version = g.version
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) databaseStats(w http.ResponseWriter, r *http.Request, org *organization) {
+	if db, ok := s.lookupDatabase(w, r, org); ok {
+		writeJSON(w, http.StatusOK, db.stats)
+	}
+}
+
+func (s *Server) transferDatabase(w http.ResponseWriter, r *http.Request, org *organization) {
+	db, ok := s.lookupDatabase(w, r, org)
+	if !ok {
+		return
+	}
+	var body turso.Body
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	target, ok := s.orgs[body.Org]
+	if !ok {
+		writeError(w, http.StatusForbidden, "you are not a member of organization "+body.Org)
+		return
+	}
+	if _, ok := target.databases[db.name]; ok {
+		writeError(w, http.StatusConflict, "database "+db.name+" already exists in organization "+body.Org)
+		return
+	}
+	if _, ok := target.groups[db.group]; !ok {
+		writeError(w, http.StatusBadRequest, "group "+db.group+" not found in organization "+body.Org)
+		return
+	}
+	delete(org.databases, db.name)
+	db.moveTo(target.org.Slug)
+	target.databases[db.name] = db
+	writeJSON(w, http.StatusOK, map[string]any{"database": db.response()})
+}
+
+func (s *Server) wakeupDatabase(w http.ResponseWriter, r *http.Request, org *organization) {
+	if db, ok := s.lookupDatabase(w, r, org); ok {
+		db.sleeping = false
+		writeJSON(w, http.StatusOK, map[string]any{})
+	}
+}
+
+func (s *Server) databaseUsage(w http.ResponseWriter, r *http.Request, org *organization) {
+	db, ok := s.lookupDatabase(w, r, org)
+	if !ok {
+		return
+	}
+	var from, to time.Time
+	for param, t := range map[string]*time.Time{"from": &from, "to": &to} {
+		if value := r.URL.Query().Get(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid "+param+" time "+value)
+				return
+			}
+			*t = parsed
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		writeError(w, http.StatusBadRequest, "to must not be before from")
+		return
+	}
+	writeJSON(w, http.StatusOK, turso.DbUsageResponse{DbUsage: db.usageResponse()})
+}
+
+func (s *Server) getDatabaseConfig(w http.ResponseWriter, r *http.Request, org *organization) {
+	if db, ok := s.lookupDatabase(w, r, org); ok {
+		writeJSON(w, http.StatusOK, db.config)
+	}
+}
+
+func (s *Server) updateDatabaseConfig(w http.ResponseWriter, r *http.Request, org *organization) {
+	db, ok := s.lookupDatabase(w, r, org)
+	if !ok {
+		return
+	}
+	if err := readJSON(r, &db.config); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid configuration")
+		return
+	}
+	writeJSON(w, http.StatusOK, db.config)
+}
+
+// Instances
+
+func (s *Server) listInstances(w http.ResponseWriter, r *http.Request, org *organization) {
+	db, ok := s.lookupDatabase(w, r, org)
+	if !ok {
+		return
+	}
+	instances := make([]map[string]any, 0, len(db.instances))
+	for _, i := range db.instances {
+		instances = append(instances, i.response())
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"instances": instances})
+}
+
+func (s *Server) createInstance(w http.ResponseWriter, r *http.Request, org *organization) {
+	db, ok := s.lookupDatabase(w, r, org)
+	if !ok {
+		return
+	}
+	var body struct{ Location string }
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if !s.validLocation(body.Location) {
+		writeError(w, http.StatusBadRequest, "invalid location "+body.Location)
+		return
+	}
+	if slices.Contains(db.regions(), body.Location) {
+		writeError(w, http.StatusConflict, fmt.Sprintf("database %s already has an instance in %s", db.name, body.Location))
+		return
+	}
+	i := db.addInstance(body.Location, "replica")
+	writeJSON(w, http.StatusOK, map[string]any{"instance": i.response()})
+}
+
+func (s *Server) deleteInstance(w http.ResponseWriter, r *http.Request, org *organization) {
+	db, ok := s.lookupDatabase(w, r, org)
+	if !ok {
+		return
+	}
+	name := r.PathValue("instance")
+	if p := db.primary(); p != nil && p.name == name {
+		writeError(w, http.StatusBadRequest, "cannot destroy the primary instance of "+db.name)
+		return
+	}
+	if !db.removeInstance(name) {
+		writeError(w, http.StatusNotFound, "instance "+name+" not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"instance": name})
+}
+
+func (s *Server) waitInstance(w http.ResponseWriter, r *http.Request, org *organization) {
+	db, ok := s.lookupDatabase(w, r, org)
+	if !ok {
+		return
+	}
+	name := r.PathValue("instance")
+	if !slices.ContainsFunc(db.instances, func(i *instance) bool { return i.name == name }) {
+		writeError(w, http.StatusNotFound, "instance "+name+" not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+// readUpload reads the file of a multipart upload, decompressing it when it
+// is gzipped, and verifies its checksum when one is sent. It returns the size
+// of the content.
+func readUpload(r *http.Request) (int64, error) {
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return 0, fmt.Errorf("missing file: %w", err)
+	}
+	defer file.Close()
+
+	var content io.Reader = file
+	if header.Header.Get("Content-Type") == "application/gzip" {
+		reader, err := gzip.NewReader(file)
+		if err != nil {
+			return 0, err
+		}
+		content = reader
+	}
+	hash := sha256.New()
+	size, err := io.Copy(hash, content)
+	if err != nil {
+		return 0, err
+	}
+	checksum := r.FormValue(turso.ChecksumField)
+	if sum := "sha256:" + hex.EncodeToString(hash.Sum(nil)); checksum != "" && checksum != sum {
+		return 0, fmt.Errorf("checksum mismatch: got %s, want %s", sum, checksum)
+	}
+	return size, nil
+}
+
+func tokenClaims(r *http.Request, idClaim, id string) map[string]any {
+	claims := map[string]any{idClaim: id}
+	if r.URL.Query().Get("authorization") == "read-only" {
+		claims["a"] = "ro"
+	}
+	if expiration := r.URL.Query().Get("expiration"); expiration != "" && expiration != "never" {
+		if d, err := time.ParseDuration(expiration); err == nil {
+			claims["exp"] = time.Now().Add(d).Unix()
+		}
+	}
+	return claims
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
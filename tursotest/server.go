@@ -0,0 +1,335 @@
+// Package tursotest provides an in-memory fake of the Turso Platform API for
+// testing code that uses turso.Client without network access.
+//
+//	server := tursotest.NewServer()
+//	defer server.Close()
+//
+//	client := server.Client(t)
+//	client.Groups.Create(ctx, "default", "ams", "latest")
+//	client.Databases.Create(ctx, "my-db", "", "", "", "default", "", false, nil)
+//
+// The fake keeps organizations, groups, databases, instances, API tokens,
+// plans and usage in memory and applies the same state transitions as the real
+// API, e.g. adding a location to a group creates a replica of every database
+// in the group. Only organization scoped paths are served, so clients must be
+// created with an organization. Failures and latency can be injected to
+// exercise error handling and retries.
+package tursotest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+)
+
+const (
+	// DefaultToken is the API token accepted by a Server unless WithToken is used.
+	DefaultToken = "tursotest-token"
+	// DefaultOrg is the organization created by NewServer unless
+	// WithOrganization is used.
+	DefaultOrg = "tursotest"
+	// DefaultUser is the username of the current user.
+	DefaultUser = "tursotest"
+)
+
+// Server is a fake Platform API served over HTTP.
+type Server struct {
+	*httptest.Server
+
+	token string
+	user  string
+
+	mu         sync.Mutex
+	orgs       map[string]*organization
+	primaryOrg string
+	customOrgs bool
+	apiTokens  map[string]turso.CreateApiToken
+	locations  map[string]string
+	plans      []turso.Plan
+	latency    time.Duration
+	failures   []*failure
+	requests   []Request
+}
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithToken sets the API token the server accepts. An empty token disables
+// authentication.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithOrganization replaces the default organization with one named slug.
+// It can be used multiple times to create several organizations.
+func WithOrganization(slug string) Option {
+	return func(s *Server) {
+		if !s.customOrgs {
+			delete(s.orgs, DefaultOrg)
+			s.primaryOrg, s.customOrgs = slug, true
+		}
+		s.orgs[slug] = newOrganization(slug)
+	}
+}
+
+// WithLocations replaces the default locations, keyed by code with their
+// description as value.
+func WithLocations(locations map[string]string) Option {
+	return func(s *Server) {
+		s.locations = locations
+	}
+}
+
+// WithPlans replaces the default plans.
+func WithPlans(plans []turso.Plan) Option {
+	return func(s *Server) {
+		s.plans = plans
+	}
+}
+
+// WithLatency delays every response by latency.
+func WithLatency(latency time.Duration) Option {
+	return func(s *Server) {
+		s.latency = latency
+	}
+}
+
+// NewServer starts a fake Platform API. It must be closed with Close.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		token:      DefaultToken,
+		user:       DefaultUser,
+		orgs:       map[string]*organization{DefaultOrg: newOrganization(DefaultOrg)},
+		primaryOrg: DefaultOrg,
+		apiTokens:  map[string]turso.CreateApiToken{},
+		locations:  DefaultLocations(),
+		plans:      DefaultPlans(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// Client returns a client for the first organization passed to
+// WithOrganization, or DefaultOrg, authenticated with the server's token. The
+// test fails when the client cannot be created, e.g. because of an invalid
+// option.
+func (s *Server) Client(tb testing.TB, opts ...turso.ClientOption) *turso.Client {
+	tb.Helper()
+	return s.ClientForOrg(tb, s.primaryOrg, opts...)
+}
+
+// ClientForOrg returns a client for the organization slug authenticated with
+// the server's token. The test fails when the client cannot be created.
+func (s *Server) ClientForOrg(tb testing.TB, slug string, opts ...turso.ClientOption) *turso.Client {
+	tb.Helper()
+	token := s.token
+	if token == "" {
+		token = DefaultToken
+	}
+	opts = append([]turso.ClientOption{turso.WithBaseUrl(s.URL)}, opts...)
+	client, err := turso.New(token, slug, opts...)
+	if err != nil {
+		tb.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+// SetLatency delays every following response by latency.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = latency
+}
+
+// Failure describes responses to inject instead of serving matching requests.
+type Failure struct {
+	// Method matches the request method. Empty matches every method.
+	Method string
+	// Path matches the request path with path.Match, e.g.
+	// "/v1/organizations/*/databases". Empty matches every path.
+	Path string
+	// Status is the status code of the injected response. Zero closes the
+	// connection without responding, simulating a network error.
+	Status int
+	// Message is sent as the error message of the injected response.
+	Message string
+	// Header is added to the injected response, e.g. Retry-After.
+	Header http.Header
+	// Times is the number of requests to fail. Zero fails every matching
+	// request until ClearFailures is called.
+	Times int
+}
+
+type failure struct {
+	Failure
+	remaining int
+}
+
+// InjectFailure makes the server fail requests matching f.
+func (s *Server) InjectFailure(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure{Failure: f, remaining: f.Times})
+}
+
+// ClearFailures removes every injected failure.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = nil
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// matchFailure returns the first injected failure matching r, consuming one of
+// its occurrences.
+func (s *Server) matchFailure(r *http.Request) *failure {
+	for i, f := range s.failures {
+		if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+			continue
+		}
+		if f.Path != "" {
+			if ok, _ := path.Match(f.Path, r.URL.Path); !ok {
+				continue
+			}
+		}
+		if f.Times > 0 {
+			f.remaining--
+			if f.remaining <= 0 {
+				s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	s.routes(mux)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Unauthorized requests do not consume injected failures.
+		authorized := s.token == "" || r.Header.Get("Authorization") == "Bearer "+s.token
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery})
+		latency := s.latency
+		var injected *failure
+		if authorized {
+			injected = s.matchFailure(r)
+		}
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if !authorized {
+			writeError(w, http.StatusUnauthorized, "token is not valid")
+			return
+		}
+
+		if injected != nil {
+			status := injected.Status
+			if status == 0 {
+				if hijacker, ok := w.(http.Hijacker); ok {
+					if conn, _, err := hijacker.Hijack(); err == nil {
+						conn.Close()
+						return
+					}
+				}
+				status = http.StatusBadGateway
+			}
+			for key, values := range injected.Header {
+				for _, value := range values {
+					w.Header().Add(key, value)
+				}
+			}
+			message := injected.Message
+			if message == "" {
+				message = http.StatusText(status)
+			}
+			writeError(w, status, message)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// DefaultPlans returns the plans served unless WithPlans is used. New
+// organizations are subscribed to the first one.
+func DefaultPlans() []turso.Plan {
+	plans := []turso.Plan{{Name: "starter", Price: "0"}, {Name: "scaler", Price: "29"}, {Name: "pro", Price: "499"}}
+	plans[0].Quotas.RowsRead, plans[0].Quotas.RowsWritten, plans[0].Quotas.Storage = 1_000_000_000, 25_000_000, 9_000_000_000
+	plans[0].Quotas.Databases, plans[0].Quotas.Locations, plans[0].Quotas.Groups = 500, 3, 1
+	plans[1].Quotas.RowsRead, plans[1].Quotas.RowsWritten, plans[1].Quotas.Storage = 100_000_000_000, 100_000_000, 24_000_000_000
+	plans[1].Quotas.Databases, plans[1].Quotas.Locations, plans[1].Quotas.Groups = 10_000, 6, 3
+	plans[2].Quotas.RowsRead, plans[2].Quotas.RowsWritten, plans[2].Quotas.Storage = 250_000_000_000, 250_000_000, 50_000_000_000
+	plans[2].Quotas.Databases, plans[2].Quotas.Locations, plans[2].Quotas.Groups = 100_000, 35, 50
+	return plans
+}
+
+// DefaultLocations returns the locations served unless WithLocations is used.
+func DefaultLocations() map[string]string {
+	return map[string]string{
+		"ams": "Amsterdam, Netherlands",
+		"arn": "Stockholm, Sweden",
+		"bog": "Bogotá, Colombia",
+		"bos": "Boston, Massachusetts (US)",
+		"cdg": "Paris, France",
+		"den": "Denver, Colorado (US)",
+		"dfw": "Dallas, Texas (US)",
+		"ewr": "Secaucus, NJ (US)",
+		"fra": "Frankfurt, Germany",
+		"gdl": "Guadalajara, Mexico",
+		"gig": "Rio de Janeiro, Brazil",
+		"gru": "São Paulo, Brazil",
+		"hkg": "Hong Kong, Hong Kong",
+		"iad": "Ashburn, Virginia (US)",
+		"jnb": "Johannesburg, South Africa",
+		"lax": "Los Angeles, California (US)",
+		"lhr": "London, United Kingdom",
+		"mad": "Madrid, Spain",
+		"mia": "Miami, Florida (US)",
+		"nrt": "Tokyo, Japan",
+		"ord": "Chicago, Illinois (US)",
+		"otp": "Bucharest, Romania",
+		"qro": "Querétaro, Mexico",
+		"scl": "Santiago, Chile",
+		"sea": "Seattle, Washington (US)",
+		"sin": "Singapore, Singapore",
+		"sjc": "San Jose, California (US)",
+		"syd": "Sydney, Australia",
+		"waw": "Warsaw, Poland",
+		"yul": "Montreal, Canada",
+		"yyz": "Toronto, Canada",
+	}
+}
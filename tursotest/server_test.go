@@ -0,0 +1,106 @@
+package tursotest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/tursotest"
+)
+
+func Test_Server_GroupLocationsReplicateDatabases(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+	ctx := context.TODO()
+
+	if err := client.Groups.Create(ctx, "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.Create(ctx, "my-db", "", "", "", "default", "", false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.Create(ctx, "my-db", "", "", "", "default", "", false, nil); !errors.Is(err, turso.ErrConflict) {
+		t.Fatalf("expected duplicate database to conflict, got: %v", err)
+	}
+
+	if err := client.Groups.AddLocation(ctx, "default", "lhr"); err != nil {
+		t.Fatal(err)
+	}
+	instances, err := client.Instances.List(ctx, "my-db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 2 || instances[0].Type != "primary" || instances[1].Region != "lhr" {
+		t.Fatalf("expected primary and lhr replica, got: %+v", instances)
+	}
+
+	if err := client.Groups.RemoveLocation(ctx, "default", "lhr"); err != nil {
+		t.Fatal(err)
+	}
+	databases, err := client.Databases.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(databases) != 1 || len(databases[0].Regions) != 1 || databases[0].PrimaryRegion != "ams" {
+		t.Fatalf("expected single-region database, got: %+v", databases)
+	}
+
+	if err := client.Databases.Delete(ctx, "my-db"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Databases.Delete(ctx, "my-db"); !errors.Is(err, turso.ErrNotFound) {
+		t.Fatalf("expected deleted database to be not found, got: %v", err)
+	}
+}
+
+func Test_Server_RejectsUnknownOrganizationsAndTokens(t *testing.T) {
+	server := tursotest.NewServer(tursotest.WithOrganization("acme"))
+	defer server.Close()
+
+	_, err := server.ClientForOrg(t, "other").Groups.List(context.TODO())
+	if !errors.Is(err, turso.ErrNotMember) {
+		t.Fatalf("expected not member error, got: %v", err)
+	}
+
+	server.InjectFailure(tursotest.Failure{Path: "/v1/organizations/acme/groups", Status: http.StatusServiceUnavailable, Times: 1})
+	client, err := turso.New("wrong-token", "acme", turso.WithBaseUrl(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Groups.List(context.TODO()); !errors.Is(err, turso.ErrUnauthorized) {
+		t.Fatalf("expected unauthorized error, got: %v", err)
+	}
+
+	var apiErr *turso.APIError
+	if _, err := server.Client(t).Groups.List(context.TODO()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected the injected failure to be left for an authorized request, got: %v", err)
+	}
+}
+
+func Test_Server_InjectedFailuresAreRetried(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t, turso.WithRetryPolicy(turso.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}))
+
+	server.InjectFailure(tursotest.Failure{Method: http.MethodGet, Path: "/v1/organizations/*/groups", Status: http.StatusServiceUnavailable, Times: 2})
+	if _, err := client.Groups.List(context.TODO()); err != nil {
+		t.Fatalf("expected request to succeed after retries, got: %v", err)
+	}
+	if requests := server.Requests(); len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+
+	server.InjectFailure(tursotest.Failure{Path: "/v1/locations"})
+	if _, err := client.Locations.List(context.TODO()); err == nil {
+		t.Fatal("expected dropped connections to fail")
+	}
+
+	server.ClearFailures()
+	if _, err := client.Locations.List(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+}
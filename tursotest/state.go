@@ -0,0 +1,282 @@
+package tursotest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/alehechka/turso-go"
+)
+
+type organization struct {
+	org          turso.Organization
+	subscription turso.Subscription
+	groups       map[string]*group
+	databases    map[string]*database
+	members      []turso.Member
+	invites      []turso.Invite
+}
+
+func newOrganization(slug string) *organization {
+	return &organization{
+		org:          turso.Organization{Name: slug, Slug: slug, Type: "team"},
+		subscription: turso.Subscription{Plan: DefaultPlans()[0].Name, Timeline: "monthly"},
+		groups:       map[string]*group{},
+		databases:    map[string]*database{},
+		members:      []turso.Member{{Name: DefaultUser, Role: "owner"}},
+	}
+}
+
+type group struct {
+	uuid      string
+	name      string
+	version   string
+	primary   string
+	locations []string
+	archived  bool
+}
+
+func (g *group) response() map[string]any {
+	return map[string]any{
+		"uuid":      g.uuid,
+		"name":      g.name,
+		"version":   g.version,
+		"primary":   g.primary,
+		"locations": g.locations,
+		"archived":  g.archived,
+	}
+}
+
+type database struct {
+	id        string
+	name      string
+	org       string
+	group     string
+	version   string
+	schema    string
+	isSchema  bool
+	sleeping  bool
+	config    turso.DatabaseConfig
+	instances []*instance
+	usage     turso.Usage
+	stats     turso.Stats
+}
+
+func (d *database) hostname() string {
+	return fmt.Sprintf("%s-%s.turso.io", d.name, d.org)
+}
+
+func (d *database) primary() *instance {
+	for _, i := range d.instances {
+		if i.typ == "primary" {
+			return i
+		}
+	}
+	return nil
+}
+
+func (d *database) regions() []string {
+	regions := make([]string, 0, len(d.instances))
+	for _, i := range d.instances {
+		regions = append(regions, i.region)
+	}
+	return regions
+}
+
+func (d *database) addInstance(region, typ string) *instance {
+	i := &instance{
+		uuid:     newUUID(),
+		name:     region,
+		typ:      typ,
+		region:   region,
+		hostname: fmt.Sprintf("%s-%s-%s.turso.io", region, d.name, d.org),
+	}
+	d.instances = append(d.instances, i)
+	return i
+}
+
+func (d *database) removeInstance(name string) bool {
+	for idx, i := range d.instances {
+		if i.name == name {
+			d.instances = slices.Delete(d.instances, idx, idx+1)
+			return true
+		}
+	}
+	return false
+}
+
+// response mirrors the shape of databases returned by the Platform API.
+func (d *database) response() map[string]any {
+	primaryRegion := ""
+	if p := d.primary(); p != nil {
+		primaryRegion = p.region
+	}
+	return map[string]any{
+		"Name":              d.name,
+		"DbId":              d.id,
+		"Hostname":          d.hostname(),
+		"regions":           d.regions(),
+		"primaryRegion":     primaryRegion,
+		"type":              "logical",
+		"version":           d.version,
+		"group":             d.group,
+		"sleeping":          d.sleeping,
+		"schema":            d.schema,
+		"is_schema":         d.isSchema,
+		"allow_attach":      d.config.AllowAttach,
+		"block_reads":       isSet(d.config.BlockReads),
+		"block_writes":      isSet(d.config.BlockWrites),
+		"delete_protection": isSet(d.config.DeleteProtection),
+		"archived":          false,
+	}
+}
+
+// moveTo renames the database and its instances after a transfer to org.
+func (d *database) moveTo(org string) {
+	d.org = org
+	for _, i := range d.instances {
+		i.hostname = fmt.Sprintf("%s-%s-%s.turso.io", i.region, d.name, org)
+	}
+}
+
+func (d *database) usageResponse() turso.DbUsage {
+	instances := make([]turso.InstanceUsage, 0, len(d.instances))
+	for _, i := range d.instances {
+		usage := turso.InstanceUsage{UUID: i.uuid}
+		if i.typ == "primary" {
+			usage.Usage = d.usage
+		}
+		instances = append(instances, usage)
+	}
+	return turso.DbUsage{UUID: d.id, Instances: instances, Usage: d.usage}
+}
+
+type instance struct {
+	uuid     string
+	name     string
+	typ      string
+	region   string
+	hostname string
+}
+
+func (i *instance) response() map[string]any {
+	return map[string]any{
+		"uuid":     i.uuid,
+		"name":     i.name,
+		"type":     i.typ,
+		"region":   i.region,
+		"hostname": i.hostname,
+	}
+}
+
+// SetUsage sets the usage reported for a database. It is attributed to the
+// database's primary instance.
+func (s *Server) SetUsage(org, database string, usage turso.Usage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orgs[org]
+	if !ok {
+		return fmt.Errorf("organization %s not found", org)
+	}
+	db, ok := o.databases[database]
+	if !ok {
+		return fmt.Errorf("database %s not found", database)
+	}
+	db.usage = usage
+	return nil
+}
+
+// SetPlan subscribes an organization to the plan with the given name.
+func (s *Server) SetPlan(org, plan string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orgs[org]
+	if !ok {
+		return fmt.Errorf("organization %s not found", org)
+	}
+	if _, ok := s.plan(plan); !ok {
+		return fmt.Errorf("plan %s not found", plan)
+	}
+	o.subscription.Plan = plan
+	return nil
+}
+
+func (s *Server) plan(name string) (turso.Plan, bool) {
+	for _, plan := range s.plans {
+		if plan.Name == name {
+			return plan, true
+		}
+	}
+	return turso.Plan{}, false
+}
+
+// SetStats sets the query statistics reported for a database.
+func (s *Server) SetStats(org, database string, stats turso.Stats) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orgs[org]
+	if !ok {
+		return fmt.Errorf("organization %s not found", org)
+	}
+	db, ok := o.databases[database]
+	if !ok {
+		return fmt.Errorf("database %s not found", database)
+	}
+	db.stats = stats
+	return nil
+}
+
+func isSet(b *bool) bool {
+	return b != nil && *b
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// newJWT returns an unsigned token shaped like the JWTs minted by the API.
+func newJWT(claims map[string]any) string {
+	encode := func(v any) string {
+		data, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	claims["iat"] = time.Now().Unix()
+	claims["id"] = newUUID()
+	return encode(map[string]string{"alg": "EdDSA", "typ": "JWT"}) + "." + encode(claims) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte("tursotest-signature"))
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func readJSON(r *http.Request, v any) error {
+	if r.Body == nil || r.ContentLength == 0 {
+		return nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
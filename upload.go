@@ -0,0 +1,333 @@
+package turso
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ChecksumField is the multipart form field carrying the checksum of an
+// uploaded file when Upload.SendChecksum is set, formatted as
+// "sha256:<hex digest>". It is sent after the file and covers the content
+// before compression.
+const ChecksumField = "checksum"
+
+// progressInterval is the minimum delay between two progress reports.
+const progressInterval = 100 * time.Millisecond
+
+// Upload describes content to upload as a multipart form file.
+type Upload struct {
+	// Reader provides the content. When it implements io.Seeker the upload is
+	// retried by rewinding it to its current offset.
+	Reader io.Reader
+	// Size is the size of the content in bytes, or 0 when it is unknown. It is
+	// reported with the progress and, unless Gzip is set, used to send the
+	// request with a Content-Length instead of chunked, so it must then be
+	// exact.
+	Size int64
+	// Name is the file name sent with the content. Defaults to "upload".
+	Name string
+	// Gzip compresses the content before sending it.
+	Gzip bool
+	// SendChecksum sends the checksum of the content in the ChecksumField form
+	// field after it. The Platform API does not define this field, so it is
+	// only useful with servers that verify it. The checksum is reported with
+	// the progress either way.
+	SendChecksum bool
+	// Spool keeps a copy of a Reader that is not seekable in a temporary file
+	// as it is sent, so a failed upload can be retried without generating the
+	// content again. Retries resume reading Reader where the previous attempt
+	// stopped. It has no effect unless the client retries POST requests.
+	Spool bool
+	// Progress, when set, is called as the content is sent.
+	Progress func(UploadProgress)
+}
+
+// UploadProgress reports the progress of an upload attempt.
+type UploadProgress struct {
+	// Attempt is the attempt number, starting at 1.
+	Attempt int
+	// Sent is the number of content bytes sent by the attempt, before
+	// compression.
+	Sent int64
+	// Total is Upload.Size.
+	Total int64
+	// Rate is the average number of content bytes sent per second by the
+	// attempt.
+	Rate float64
+	// Done reports whether the whole content was sent.
+	Done bool
+	// Checksum is the checksum of the content, before compression, formatted
+	// as "sha256:<hex digest>". It is set when Done.
+	Checksum string
+}
+
+// UploadReader sends upload as a multipart form file, followed by its
+// checksum when Upload.SendChecksum is set.
+func (c *Client) UploadReader(ctx context.Context, path string, upload Upload) (*http.Response, error) {
+	if upload.Reader == nil {
+		return nil, fmt.Errorf("%w: upload reader is required", ErrInvalidOptions)
+	}
+	if upload.Name == "" {
+		upload.Name = "upload"
+	}
+
+	source, cleanup, err := c.uploadSource(upload)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	attempt := 0
+	var previous chan struct{}
+	var previousBody *io.PipeReader
+	contentLength, err := uploadLength(boundary, upload)
+	if err != nil {
+		return nil, err
+	}
+	reqBody := &requestBody{
+		contentType:   "multipart/form-data; boundary=" + boundary,
+		contentLength: contentLength,
+		replayable:    source.replayable,
+		open: func() (io.Reader, error) {
+			// The writer of a failed attempt must be done with the content
+			// before it is rewound.
+			if previous != nil {
+				previousBody.Close()
+				<-previous
+			}
+			content, err := source.open()
+			if err != nil {
+				return nil, err
+			}
+			attempt++
+			progress := &progressReader{reader: content, upload: upload, attempt: attempt, start: time.Now()}
+
+			body, bodyWriter := io.Pipe()
+			writer := multipart.NewWriter(bodyWriter)
+			if err := writer.SetBoundary(boundary); err != nil {
+				return nil, err
+			}
+			done := make(chan struct{})
+			previous, previousBody = done, body
+			go func() {
+				defer close(done)
+				bodyWriter.CloseWithError(writeUpload(writer, upload, progress))
+			}()
+			return body, nil
+		},
+	}
+	resp, err := c.do(ctx, http.MethodPost, path, reqBody)
+	// The writer of the last attempt is still blocked when its body was not
+	// read to the end, e.g. when the request could not be built or a
+	// middleware answered without sending it. It must be stopped before the
+	// spool is removed.
+	if previous != nil {
+		previousBody.Close()
+		<-previous
+	}
+	return resp, err
+}
+
+func writeUpload(writer *multipart.Writer, upload Upload, progress *progressReader) error {
+	part, err := writer.CreatePart(uploadHeader(upload))
+	if err != nil {
+		return err
+	}
+
+	checksum := sha256.New()
+	var dst io.Writer = part
+	var compressor *gzip.Writer
+	if upload.Gzip {
+		compressor = gzip.NewWriter(dst)
+		dst = compressor
+	}
+	if _, err := io.Copy(dst, io.TeeReader(progress, checksum)); err != nil {
+		return err
+	}
+	if compressor != nil {
+		if err := compressor.Close(); err != nil {
+			return err
+		}
+	}
+
+	sum := formatChecksum(checksum)
+	if upload.SendChecksum {
+		if err := writer.WriteField(ChecksumField, sum); err != nil {
+			return err
+		}
+	}
+	progress.done(sum)
+	return writer.Close()
+}
+
+func uploadHeader(upload Upload) textproto.MIMEHeader {
+	header := make(textproto.MIMEHeader)
+	name, contentType := upload.Name, "application/octet-stream"
+	if upload.Gzip {
+		name, contentType = name+".gz", "application/gzip"
+	}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, escapeQuotes(name)))
+	header.Set("Content-Type", contentType)
+	return header
+}
+
+// uploadLength returns the length of the multipart body writeUpload writes
+// for upload, or 0 when it is not known in advance.
+func uploadLength(boundary string, upload Upload) (int64, error) {
+	if upload.Size <= 0 || upload.Gzip {
+		return 0, nil
+	}
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return 0, err
+	}
+	if _, err := writer.CreatePart(uploadHeader(upload)); err != nil {
+		return 0, err
+	}
+	if upload.SendChecksum {
+		// Every checksum has the length of the checksum of no content.
+		if err := writer.WriteField(ChecksumField, formatChecksum(sha256.New())); err != nil {
+			return 0, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return 0, err
+	}
+	return int64(buf.Len()) + upload.Size, nil
+}
+
+func formatChecksum(h hash.Hash) string {
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// uploadSource opens the content of an upload for every attempt.
+type uploadSource struct {
+	open       func() (io.Reader, error)
+	replayable bool
+}
+
+func (c *Client) uploadSource(upload Upload) (*uploadSource, func(), error) {
+	noop := func() {}
+	if seeker, ok := upload.Reader.(io.Seeker); ok {
+		if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			return &uploadSource{
+				replayable: true,
+				open: func() (io.Reader, error) {
+					if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+						return nil, err
+					}
+					return upload.Reader, nil
+				},
+			}, noop, nil
+		}
+	}
+
+	if !upload.Spool || c.retryPolicy.attempts(http.MethodPost) == 1 {
+		return &uploadSource{open: func() (io.Reader, error) { return upload.Reader, nil }}, noop, nil
+	}
+
+	file, err := os.CreateTemp("", "turso-upload-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create upload spool: %w", err)
+	}
+	s := &spool{source: upload.Reader, file: file}
+	cleanup := func() {
+		file.Close()
+		os.Remove(file.Name())
+	}
+	return &uploadSource{open: s.open, replayable: true}, cleanup, nil
+}
+
+// spool copies what is read from source to file, so every attempt replays
+// what previous attempts read before reading more from source.
+type spool struct {
+	source io.Reader
+
+	mu      sync.Mutex
+	file    *os.File
+	spooled int64
+}
+
+func (s *spool) open() (io.Reader, error) {
+	s.mu.Lock()
+	spooled := s.spooled
+	s.mu.Unlock()
+	return io.MultiReader(io.NewSectionReader(s.file, 0, spooled), &spoolReader{spool: s}), nil
+}
+
+type spoolReader struct {
+	spool *spool
+}
+
+func (r *spoolReader) Read(p []byte) (int, error) {
+	s := r.spool
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, err := s.source.Read(p)
+	if n > 0 {
+		if _, werr := s.file.WriteAt(p[:n], s.spooled); werr != nil {
+			return 0, fmt.Errorf("failed to spool upload: %w", werr)
+		}
+		s.spooled += int64(n)
+	}
+	return n, err
+}
+
+// progressReader reports the content read by an attempt.
+type progressReader struct {
+	reader   io.Reader
+	upload   Upload
+	attempt  int
+	start    time.Time
+	sent     int64
+	reported time.Time
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.sent += int64(n)
+	if r.upload.Progress != nil && time.Since(r.reported) >= progressInterval {
+		r.reported = time.Now()
+		r.upload.Progress(r.progress())
+	}
+	return n, err
+}
+
+func (r *progressReader) done(checksum string) {
+	if r.upload.Progress == nil {
+		return
+	}
+	progress := r.progress()
+	progress.Done, progress.Checksum = true, checksum
+	r.upload.Progress(progress)
+}
+
+func (r *progressReader) progress() UploadProgress {
+	progress := UploadProgress{Attempt: r.attempt, Sent: r.sent, Total: r.upload.Size}
+	if elapsed := time.Since(r.start).Seconds(); elapsed > 0 {
+		progress.Rate = float64(r.sent) / elapsed
+	}
+	return progress
+}
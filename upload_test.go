@@ -0,0 +1,244 @@
+package turso_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/tursotest"
+)
+
+// onlyReader hides every method of the wrapped reader but Read.
+type onlyReader struct {
+	io.Reader
+}
+
+func Test_Upload_ProgressAndChecksum(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+	ctx := context.TODO()
+
+	if err := client.Groups.Create(ctx, "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.Create(ctx, "my-db", "", "", "", "default", "", false, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	content := strings.Repeat("SQLite format 3\x00", 4096)
+	sum := sha256.Sum256([]byte(content))
+	var last turso.UploadProgress
+	upload := turso.Upload{
+		Reader:       onlyReader{strings.NewReader(content)},
+		Size:         int64(len(content)),
+		Name:         "my-db.sqlite",
+		SendChecksum: true,
+		Progress:     func(p turso.UploadProgress) { last = p },
+	}
+	if err := client.Databases.SeedReader(ctx, "my-db", upload); err != nil {
+		t.Fatal(err)
+	}
+	if !last.Done || last.Sent != int64(len(content)) || last.Total != int64(len(content)) || last.Attempt != 1 {
+		t.Fatalf("unexpected final progress: %+v", last)
+	}
+	if last.Checksum != "sha256:"+hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected checksum: %s", last.Checksum)
+	}
+
+	dumpURL, err := client.Databases.UploadDumpReader(ctx, turso.Upload{Reader: strings.NewReader("CREATE TABLE t (id INTEGER);"), Gzip: true, SendChecksum: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dumpURL == "" {
+		t.Fatal("expected a dump url")
+	}
+}
+
+func Test_Upload_ContentLength(t *testing.T) {
+	type request struct {
+		contentLength int64
+		chunked       bool
+		checksum      string
+	}
+	var received request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = request{contentLength: r.ContentLength, chunked: slices.Contains(r.TransferEncoding, "chunked")}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received.checksum = r.FormValue(turso.ChecksumField)
+		w.Write([]byte(`{"dump_url": "https://example.com/dump.sql"}`))
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := strings.Repeat("INSERT INTO t VALUES (1);\n", 1000)
+
+	for _, sendChecksum := range []bool{false, true} {
+		upload := turso.Upload{Reader: onlyReader{strings.NewReader(content)}, Size: int64(len(content)), SendChecksum: sendChecksum}
+		if _, err := client.Databases.UploadDumpReader(context.TODO(), upload); err != nil {
+			t.Fatal(err)
+		}
+		if received.chunked || received.contentLength <= int64(len(content)) {
+			t.Fatalf("expected a known size to be sent as the Content-Length, got: %+v", received)
+		}
+		if (received.checksum != "") != sendChecksum {
+			t.Fatalf("expected the checksum to be sent only when requested, got: %+v", received)
+		}
+	}
+
+	upload := turso.Upload{Reader: onlyReader{strings.NewReader(content)}, Size: int64(len(content)), Gzip: true}
+	if _, err := client.Databases.UploadDumpReader(context.TODO(), upload); err != nil {
+		t.Fatal(err)
+	}
+	if !received.chunked {
+		t.Fatalf("expected a compressed upload to be chunked, got: %+v", received)
+	}
+}
+
+func Test_Upload_Gzip(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		if header.Filename != "dump.sql.gz" || header.Header.Get("Content-Type") != "application/gzip" {
+			http.Error(w, "unexpected file header", http.StatusBadRequest)
+			return
+		}
+		reader, err := gzip.NewReader(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(reader)
+		received = string(data)
+		w.Write([]byte(`{"dump_url": "https://example.com/dump.sql"}`))
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := strings.Repeat("INSERT INTO t VALUES (1);\n", 1000)
+	var checksum string
+	upload := turso.Upload{
+		Reader:   strings.NewReader(content),
+		Name:     "dump.sql",
+		Gzip:     true,
+		Progress: func(p turso.UploadProgress) { checksum = p.Checksum },
+	}
+	if _, err := client.Databases.UploadDumpReader(context.TODO(), upload); err != nil {
+		t.Fatal(err)
+	}
+	if received != content {
+		t.Fatalf("expected the decompressed content to match, got %d bytes", len(received))
+	}
+	if sum := sha256.Sum256([]byte(content)); checksum != "sha256:"+hex.EncodeToString(sum[:]) {
+		t.Fatalf("expected the checksum of the uncompressed content, got: %s", checksum)
+	}
+}
+
+func Test_Upload_Retry(t *testing.T) {
+	content := strings.Repeat("-- generated on the fly\n", 10000)
+	tests := map[string]turso.Upload{
+		"seeker": {Reader: bytes.NewReader([]byte(content))},
+		"spool":  {Reader: onlyReader{strings.NewReader(content)}, Spool: true},
+	}
+
+	for name, upload := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := tursotest.NewServer()
+			defer server.Close()
+			client := server.Client(t, turso.WithRetryPolicy(turso.RetryPolicy{
+				MaxAttempts: 3,
+				MinBackoff:  time.Millisecond,
+				MaxBackoff:  time.Millisecond,
+				RetryPOST:   true,
+			}))
+
+			server.InjectFailure(tursotest.Failure{Path: "/v1/organizations/*/databases/dumps", Status: http.StatusServiceUnavailable, Times: 1})
+			var attempts []int
+			upload.Progress = func(p turso.UploadProgress) {
+				if p.Done {
+					attempts = append(attempts, p.Attempt)
+				}
+			}
+			if _, err := client.Databases.UploadDumpReader(context.TODO(), upload); err != nil {
+				t.Fatal(err)
+			}
+			if len(attempts) == 0 || attempts[len(attempts)-1] != 2 {
+				t.Fatalf("expected the upload to complete on the second attempt, got: %v", attempts)
+			}
+		})
+	}
+}
+
+func Test_Upload_NotReplayable(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t, turso.WithRetryPolicy(turso.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, RetryPOST: true}))
+
+	server.InjectFailure(tursotest.Failure{Path: "/v1/organizations/*/databases/dumps", Status: http.StatusServiceUnavailable, Times: 1})
+	upload := turso.Upload{Reader: onlyReader{strings.NewReader("CREATE TABLE t (id INTEGER);")}}
+	if _, err := client.Databases.UploadDumpReader(context.TODO(), upload); err == nil {
+		t.Fatal("expected a reader that is neither seekable nor spooled not to be retried")
+	}
+}
+
+func Test_Upload_RequiresReader(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+
+	if err := client.Databases.SeedReader(context.TODO(), "my-db", turso.Upload{}); !errors.Is(err, turso.ErrInvalidOptions) {
+		t.Fatalf("expected ErrInvalidOptions, got: %v", err)
+	}
+	if _, err := client.Databases.UploadDumpReader(context.TODO(), turso.Upload{}); !errors.Is(err, turso.ErrInvalidOptions) {
+		t.Fatalf("expected ErrInvalidOptions, got: %v", err)
+	}
+}
+
+func Test_Upload_StopsWriterWhenBodyIsNotSent(t *testing.T) {
+	shortCircuit := func(next turso.Doer) turso.Doer {
+		return turso.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+		})
+	}
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl("http://localhost"), turso.WithMiddleware(shortCircuit))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 20; i++ {
+		upload := turso.Upload{Reader: onlyReader{strings.NewReader(strings.Repeat("x", 1<<16))}}
+		if err := client.Databases.SeedReader(context.TODO(), "my-db", upload); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("expected upload writers to be stopped, got %d goroutines before and %d after", before, after)
+	}
+}
@@ -18,9 +18,10 @@ type UserInfoResponse struct {
 }
 
 func (c *UsersClient) GetUser(ctx context.Context) (UserInfo, error) {
+	ctx = WithOperation(ctx, "users.get_user")
 	res, err := c.client.Get(ctx, "/v1/current-user", nil)
 	if err != nil {
-		return UserInfo{}, fmt.Errorf("failed to get user info: %s", err)
+		return UserInfo{}, fmt.Errorf("failed to get user info: %w", err)
 	}
 	defer res.Body.Close()
 
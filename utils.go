@@ -3,19 +3,44 @@ package turso
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 )
 
+// unmarshal decodes the JSON body of r as it is read. Failures are reported as
+// a DecodeError carrying the start of the body.
 func unmarshal[T any](r *http.Response) (T, error) {
-	d, err := io.ReadAll(r.Body)
-	t := new(T)
-	if err != nil {
-		return *t, err
+	var t T
+	snippet := &snippetWriter{}
+	if err := json.NewDecoder(io.TeeReader(r.Body, snippet)).Decode(&t); err != nil {
+		return t, &DecodeError{
+			Operation:  OperationFromContext(responseContext(r)),
+			StatusCode: r.StatusCode,
+			Snippet:    redactCredentials(snippet.String()),
+			Err:        err,
+			truncated:  snippet.truncated,
+		}
 	}
-	err = json.Unmarshal(d, &t)
-	return *t, err
+	return t, nil
+}
+
+// snippetWriter keeps the first maxSnippetSize bytes written to it.
+type snippetWriter struct {
+	buf       []byte
+	truncated bool
+}
+
+func (w *snippetWriter) Write(p []byte) (int, error) {
+	room := maxSnippetSize - len(w.buf)
+	if len(p) > room {
+		w.truncated = true
+	}
+	w.buf = append(w.buf, p[:min(max(room, 0), len(p))]...)
+	return len(p), nil
+}
+
+func (w *snippetWriter) String() string {
+	return string(w.buf)
 }
 
 func marshal(data interface{}) (io.Reader, error) {
@@ -23,11 +48,3 @@ func marshal(data interface{}) (io.Reader, error) {
 	err := json.NewEncoder(buf).Encode(data)
 	return buf, err
 }
-
-func parseResponseError(res *http.Response) error {
-	type ErrorResponse struct{ Error interface{} }
-	if result, err := unmarshal[ErrorResponse](res); err == nil {
-		return fmt.Errorf("%s", result.Error)
-	}
-	return fmt.Errorf("response failed with status %s", res.Status)
-}
@@ -0,0 +1,528 @@
+package turso
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// ValidationCheck identifies a check performed before uploading a file.
+type ValidationCheck string
+
+const (
+	// CheckHeader verifies that a file is a SQLite database, or that a dump is
+	// not one.
+	CheckHeader ValidationCheck = "header"
+	// CheckPageSize verifies the page size of a database and that its size is
+	// a whole number of pages.
+	CheckPageSize ValidationCheck = "page_size"
+	// CheckJournalMode verifies that a database is not in WAL mode and has no
+	// pending WAL file.
+	CheckJournalMode ValidationCheck = "journal_mode"
+	// CheckSize verifies the size of a database against the storage quota of
+	// the organization's plan.
+	CheckSize ValidationCheck = "size"
+	// CheckSyntax verifies that a dump is a sequence of complete SQL
+	// statements.
+	CheckSyntax ValidationCheck = "syntax"
+)
+
+// ValidationProblem is a problem found while validating a file.
+type ValidationProblem struct {
+	Check   ValidationCheck
+	Message string
+	// Line is the line of a dump the problem was found at, or 0.
+	Line int
+}
+
+func (p ValidationProblem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s: line %d: %s", p.Check, p.Line, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", p.Check, p.Message)
+}
+
+// ValidationReport describes a database or dump file validated before it is
+// uploaded.
+type ValidationReport struct {
+	// Size is the size of the file in bytes.
+	Size int64
+	// PageSize is the page size of a database in bytes.
+	PageSize int
+	// JournalMode is "rollback" or "wal" for a database.
+	JournalMode string
+	// Statements is the number of statements of a dump.
+	Statements int
+	// Problems lists what would make the upload fail. The file is valid when
+	// it is empty.
+	Problems []ValidationProblem
+	// Skipped lists the checks that could not be run, with the reason in
+	// their message. They do not make the file invalid.
+	Skipped []ValidationProblem
+}
+
+// Valid reports whether no problem was found.
+func (r *ValidationReport) Valid() bool {
+	return len(r.Problems) == 0
+}
+
+// Err returns a *ValidationError when problems were found, nil otherwise.
+func (r *ValidationReport) Err() error {
+	if r.Valid() {
+		return nil
+	}
+	return &ValidationError{Report: r}
+}
+
+func (r *ValidationReport) add(check ValidationCheck, line int, format string, args ...any) {
+	r.Problems = append(r.Problems, ValidationProblem{Check: check, Message: fmt.Sprintf(format, args...), Line: line})
+}
+
+func (r *ValidationReport) skip(check ValidationCheck, format string, args ...any) {
+	r.Skipped = append(r.Skipped, ValidationProblem{Check: check, Message: fmt.Sprintf(format, args...)})
+}
+
+// ValidationError is returned by ValidationReport.Err. Use errors.As to access
+// it.
+type ValidationError struct {
+	Report *ValidationReport
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Report.Problems))
+	for i, problem := range e.Report.Problems {
+		problems[i] = problem.String()
+	}
+	return "file failed validation: " + strings.Join(problems, "; ")
+}
+
+// ValidateOptions configures ValidateDatabase and ValidateDump.
+type ValidateOptions struct {
+	// MaxSize is the largest accepted database size in bytes, or 0 for no
+	// limit. It does not apply to dumps, whose size differs from the size of
+	// the database they create.
+	MaxSize int64
+}
+
+const (
+	// sqliteHeaderSize is the size of the header of a SQLite database.
+	sqliteHeaderSize = 100
+	// maxDumpProblems caps the number of syntax problems reported for a dump.
+	maxDumpProblems = 50
+)
+
+// sqliteMagic starts every SQLite database.
+var sqliteMagic = []byte("SQLite format 3\x00")
+
+// ValidateDatabase reads a SQLite database from r and reports the problems
+// that would make seeding a database with it fail. The returned error is only
+// set when r cannot be read.
+func ValidateDatabase(r io.Reader, opts ValidateOptions) (*ValidationReport, error) {
+	report := &ValidationReport{}
+	header := make([]byte, sqliteHeaderSize)
+	n, err := io.ReadFull(r, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read database: %w", err)
+	}
+	rest, err := io.Copy(io.Discard, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database: %w", err)
+	}
+	report.Size = int64(n) + rest
+
+	if n < sqliteHeaderSize || !bytes.HasPrefix(header, sqliteMagic) {
+		report.add(CheckHeader, 0, "not a SQLite database")
+		return report, nil
+	}
+
+	// The page size is stored big-endian at offset 16, 1 meaning 65536.
+	pageSize := int(binary.BigEndian.Uint16(header[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	report.PageSize = pageSize
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		report.add(CheckPageSize, 0, "invalid page size %d", pageSize)
+	} else if report.Size%int64(pageSize) != 0 {
+		report.add(CheckPageSize, 0, "size %d is not a multiple of the page size %d, the file is truncated", report.Size, pageSize)
+	} else if pages := int64(binary.BigEndian.Uint32(header[28:32])); pages > 0 &&
+		bytes.Equal(header[24:28], header[92:96]) && pages*int64(pageSize) > report.Size {
+		// The page count is only valid when the change counter matches the
+		// version-valid-for number.
+		report.add(CheckPageSize, 0, "header declares %d pages but the file holds %d, the file is truncated", pages, report.Size/int64(pageSize))
+	}
+
+	// The file format write and read versions are 1 for rollback journals and
+	// 2 for WAL.
+	switch write, read := header[18], header[19]; {
+	case write == 2 || read == 2:
+		report.JournalMode = "wal"
+		report.add(CheckJournalMode, 0, "database is in WAL mode, run PRAGMA journal_mode=DELETE before uploading it")
+	case write == 1 && read == 1:
+		report.JournalMode = "rollback"
+	default:
+		report.add(CheckHeader, 0, "unsupported file format version %d.%d", write, read)
+	}
+
+	if opts.MaxSize > 0 && report.Size > opts.MaxSize {
+		report.add(CheckSize, 0, "size %d exceeds the limit of %d bytes", report.Size, opts.MaxSize)
+	}
+	return report, nil
+}
+
+// ValidateDump reads a SQL dump from r and reports the problems that would
+// make uploading it fail: unterminated statements, strings and comments,
+// statements a dump is not expected to contain and unbalanced transactions.
+// The returned error is only set when r cannot be read.
+func ValidateDump(r io.Reader, opts ValidateOptions) (*ValidationReport, error) {
+	reader := bufio.NewReaderSize(r, 64<<10)
+	report := &ValidationReport{}
+	if prefix, _ := reader.Peek(len(sqliteMagic)); bytes.Equal(prefix, sqliteMagic) {
+		report.add(CheckHeader, 0, "file is a SQLite database, not a SQL dump")
+		size, err := io.Copy(io.Discard, reader)
+		report.Size = size
+		return report, err
+	}
+
+	s := &dumpScanner{reader: reader, report: report, line: 1}
+	if err := s.scan(); err != nil {
+		return nil, fmt.Errorf("failed to read dump: %w", err)
+	}
+	return report, nil
+}
+
+// dumpStatements are the statements expected in a SQL dump.
+var dumpStatements = map[string]bool{
+	"ALTER": true, "ANALYZE": true, "BEGIN": true, "COMMIT": true, "CREATE": true,
+	"DELETE": true, "DROP": true, "END": true, "INSERT": true, "PRAGMA": true,
+	"REINDEX": true, "RELEASE": true, "REPLACE": true, "ROLLBACK": true,
+	"SAVEPOINT": true, "SELECT": true, "UPDATE": true, "VACUUM": true, "WITH": true,
+}
+
+// dumpScanner splits a dump into statements without parsing them. It only
+// tracks what decides where a statement ends: quotes, comments and the
+// BEGIN ... END body of triggers.
+type dumpScanner struct {
+	reader *bufio.Reader
+	report *ValidationReport
+
+	line     int
+	problems int
+	binary   bool
+
+	word  strings.Builder
+	words []string
+	// depth counts the BEGIN and CASE blocks of a trigger that are not closed
+	// by END yet. Semicolons inside them do not end the statement.
+	depth     int
+	start     int
+	empty     bool
+	open      int
+	openStart int
+}
+
+func (s *dumpScanner) scan() error {
+	s.empty = true
+	for {
+		c, err := s.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case isWordByte(c):
+			s.begin()
+			s.word.WriteByte(c)
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			s.flush()
+			s.begin()
+			if err := s.skipQuoted(c); err != nil {
+				return err
+			}
+		case c == '-' && s.peek('-'):
+			s.flush()
+			if err := s.skipLineComment(); err != nil {
+				return err
+			}
+		case c == '/' && s.peek('*'):
+			s.flush()
+			if err := s.skipBlockComment(); err != nil {
+				return err
+			}
+		case c == ';':
+			s.flush()
+			s.end()
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			s.flush()
+		default:
+			s.flush()
+			s.begin()
+		}
+	}
+
+	s.flush()
+	if !s.empty {
+		s.problem(s.start, "statement is not terminated by a semicolon")
+	}
+	if s.open > 0 {
+		s.problem(s.openStart, "transaction is never committed")
+	}
+	if s.problems > maxDumpProblems {
+		s.report.add(CheckSyntax, 0, "%d more problems omitted", s.problems-maxDumpProblems)
+	}
+	return nil
+}
+
+// next returns the next byte of the dump, counting lines.
+func (s *dumpScanner) next() (byte, error) {
+	c, err := s.reader.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	s.report.Size++
+	switch c {
+	case '\n':
+		s.line++
+	case 0:
+		if !s.binary {
+			s.binary = true
+			s.problem(s.line, "dump contains binary data")
+		}
+	}
+	return c, nil
+}
+
+// peek consumes the next byte when it is c.
+func (s *dumpScanner) peek(c byte) bool {
+	next, err := s.reader.Peek(1)
+	if err != nil || next[0] != c {
+		return false
+	}
+	s.next()
+	return true
+}
+
+func (s *dumpScanner) skipQuoted(quote byte) error {
+	start, closing := s.line, quote
+	if quote == '[' {
+		closing = ']'
+	}
+	for {
+		c, err := s.next()
+		if err == io.EOF {
+			s.problem(start, "unterminated %c", quote)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// Quotes are escaped by doubling them, except for brackets.
+		if c == closing && (quote == '[' || !s.peek(closing)) {
+			return nil
+		}
+	}
+}
+
+func (s *dumpScanner) skipLineComment() error {
+	for {
+		c, err := s.next()
+		if err == io.EOF || c == '\n' {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *dumpScanner) skipBlockComment() error {
+	start := s.line
+	for {
+		c, err := s.next()
+		if err == io.EOF {
+			s.problem(start, "unterminated comment")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if c == '*' && s.peek('/') {
+			return nil
+		}
+	}
+}
+
+// begin records the start of a statement.
+func (s *dumpScanner) begin() {
+	if s.empty {
+		s.empty, s.start = false, s.line
+	}
+}
+
+// flush ends the current word.
+func (s *dumpScanner) flush() {
+	if s.word.Len() == 0 {
+		return
+	}
+	word := strings.ToUpper(s.word.String())
+	s.word.Reset()
+	if len(s.words) < 3 {
+		s.words = append(s.words, word)
+	}
+	if !s.isTrigger() {
+		return
+	}
+	switch word {
+	case "BEGIN", "CASE":
+		s.depth++
+	case "END":
+		s.depth--
+	}
+}
+
+// end handles a semicolon, which ends the current statement unless it is in
+// the body of a trigger.
+func (s *dumpScanner) end() {
+	if s.empty {
+		return
+	}
+	if s.isTrigger() && s.depth > 0 {
+		return
+	}
+
+	s.report.Statements++
+	keyword := ""
+	if len(s.words) > 0 {
+		keyword = s.words[0]
+	}
+	switch {
+	case !dumpStatements[keyword]:
+		s.problem(s.start, "unexpected statement %q in a dump", keyword)
+	case keyword == "BEGIN":
+		if s.open == 0 {
+			s.openStart = s.start
+		}
+		s.open++
+	case (keyword == "COMMIT" || keyword == "END" || keyword == "ROLLBACK") && !s.isSavepointRollback():
+		if s.open == 0 {
+			s.problem(s.start, "%s without a transaction", keyword)
+		} else {
+			s.open--
+		}
+	}
+	s.words, s.depth, s.empty = s.words[:0], 0, true
+}
+
+func (s *dumpScanner) isTrigger() bool {
+	if len(s.words) < 2 || s.words[0] != "CREATE" {
+		return false
+	}
+	if s.words[1] == "TEMP" || s.words[1] == "TEMPORARY" {
+		return len(s.words) > 2 && s.words[2] == "TRIGGER"
+	}
+	return s.words[1] == "TRIGGER"
+}
+
+// isSavepointRollback reports whether the statement is ROLLBACK TO, which
+// does not end the transaction.
+func (s *dumpScanner) isSavepointRollback() bool {
+	return s.words[0] == "ROLLBACK" && slices.Contains(s.words[1:], "TO")
+}
+
+func (s *dumpScanner) problem(line int, format string, args ...any) {
+	s.problems++
+	if s.problems <= maxDumpProblems {
+		s.report.add(CheckSyntax, line, format, args...)
+	}
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// ValidateSeed validates dbFile before it is passed to Seed, including its
+// size against the storage left in the quota of the organization's plan, i.e.
+// the quota minus the storage the organization already uses, and the presence
+// of a WAL file next to it. When the quota or the usage cannot be fetched,
+// e.g. because the token cannot read the subscription, the size check is
+// listed in ValidationReport.Skipped. dbFile is read from its current offset,
+// which is restored afterwards.
+func (c *DatabasesClient) ValidateSeed(ctx context.Context, dbFile *os.File) (*ValidationReport, error) {
+	ctx = WithOperation(ctx, "databases.validate_seed")
+	quota, used, quotaErr := c.storage(ctx)
+	if errors.Is(quotaErr, context.Canceled) || errors.Is(quotaErr, context.DeadlineExceeded) {
+		return nil, quotaErr
+	}
+	available := quota - used
+
+	report, err := validateFile(dbFile, func(r io.Reader) (*ValidationReport, error) {
+		return ValidateDatabase(r, ValidateOptions{MaxSize: max(available, 0)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case quotaErr != nil:
+		report.skip(CheckSize, "storage quota unknown: %v", quotaErr)
+	case quota > 0 && available <= 0:
+		report.add(CheckSize, 0, "the organization already uses %d bytes of its storage quota of %d bytes", used, quota)
+	}
+	if info, err := os.Stat(dbFile.Name() + "-wal"); err == nil && info.Size() > 0 {
+		report.add(CheckJournalMode, 0, "%s-wal holds changes missing from the database, checkpoint it before uploading", dbFile.Name())
+	}
+	return report, nil
+}
+
+// ValidateDump validates dumpFile before it is passed to UploadDump. dumpFile
+// is read from its current offset, which is restored afterwards.
+func (c *DatabasesClient) ValidateDump(ctx context.Context, dumpFile *os.File) (*ValidationReport, error) {
+	return validateFile(dumpFile, func(r io.Reader) (*ValidationReport, error) {
+		return ValidateDump(r, ValidateOptions{})
+	})
+}
+
+func validateFile(file *os.File, validate func(io.Reader) (*ValidationReport, error)) (*ValidationReport, error) {
+	offset, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file.Name(), err)
+	}
+	report, err := validate(file)
+	if _, seekErr := file.Seek(offset, io.SeekStart); err == nil && seekErr != nil {
+		err = fmt.Errorf("failed to rewind %s: %w", file.Name(), seekErr)
+	}
+	return report, err
+}
+
+// storage returns the storage quota in bytes of the plan the organization is
+// subscribed to and the storage in bytes it already uses, or an error when
+// either is unknown.
+func (c *DatabasesClient) storage(ctx context.Context) (quota, used int64, err error) {
+	subscription, err := c.client.Subscriptions.Get(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	plans, err := c.client.Plans.List(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	i := slices.IndexFunc(plans, func(plan Plan) bool { return plan.Name == subscription.Plan })
+	if i < 0 {
+		return 0, 0, fmt.Errorf("plan %s is not listed", subscription.Plan)
+	}
+	usage, err := c.client.Organizations.Usage(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	// The Platform API reports plan storage quotas and storage usage in bytes.
+	return int64(plans[i].Quotas.Storage), int64(usage.Usage.StorageBytesUsed), nil
+}
@@ -0,0 +1,271 @@
+package turso_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/tursotest"
+)
+
+// sqliteFile returns a database of the given number of pages with a valid
+// header.
+func sqliteFile(pageSize, pages int, wal bool) []byte {
+	data := make([]byte, pageSize*pages)
+	copy(data, "SQLite format 3\x00")
+	binary.BigEndian.PutUint16(data[16:], uint16(pageSize))
+	data[18], data[19] = 1, 1
+	if wal {
+		data[18], data[19] = 2, 2
+	}
+	binary.BigEndian.PutUint32(data[24:], 7)
+	binary.BigEndian.PutUint32(data[28:], uint32(pages))
+	binary.BigEndian.PutUint32(data[92:], 7)
+	return data
+}
+
+func checks(report *turso.ValidationReport) []turso.ValidationCheck {
+	var checks []turso.ValidationCheck
+	for _, problem := range report.Problems {
+		checks = append(checks, problem.Check)
+	}
+	return checks
+}
+
+func Test_ValidateDatabase(t *testing.T) {
+	tests := map[string]struct {
+		data    []byte
+		maxSize int64
+		want    []turso.ValidationCheck
+	}{
+		"valid":      {data: sqliteFile(4096, 3, false)},
+		"not sqlite": {data: []byte("CREATE TABLE t (id INTEGER);"), want: []turso.ValidationCheck{turso.CheckHeader}},
+		"page size":  {data: sqliteFile(1000, 2, false), want: []turso.ValidationCheck{turso.CheckPageSize}},
+		"truncated":  {data: sqliteFile(4096, 3, false)[:4096*2], want: []turso.ValidationCheck{turso.CheckPageSize}},
+		"wal":        {data: sqliteFile(4096, 1, true), want: []turso.ValidationCheck{turso.CheckJournalMode}},
+		"too large":  {data: sqliteFile(4096, 3, false), maxSize: 8192, want: []turso.ValidationCheck{turso.CheckSize}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			report, err := turso.ValidateDatabase(bytes.NewReader(tt.data), turso.ValidateOptions{MaxSize: tt.maxSize})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := checks(report); !slices.Equal(got, tt.want) {
+				t.Fatalf("expected problems %v, got: %v", tt.want, report.Problems)
+			}
+			if report.Size != int64(len(tt.data)) {
+				t.Fatalf("expected size %d, got %d", len(tt.data), report.Size)
+			}
+		})
+	}
+}
+
+func Test_ValidateDump(t *testing.T) {
+	valid := `PRAGMA foreign_keys=OFF;
+BEGIN TRANSACTION;
+CREATE TABLE "t" (id INTEGER, name TEXT); -- a comment; with a semicolon
+INSERT INTO t VALUES(1,'it''s; fine');
+/* block; comment */
+CREATE TRIGGER tr AFTER INSERT ON t BEGIN
+  UPDATE t SET name = 'x' WHERE id = new.id;
+END;
+CREATE TRIGGER tr_case AFTER UPDATE ON t BEGIN
+  UPDATE t SET name = CASE WHEN new.id > 0 THEN 'positive' ELSE 'other' END;
+  UPDATE t SET name = CASE new.name WHEN 'a' THEN 'b' END WHERE id = new.id;
+END;
+COMMIT;
+`
+	report, err := turso.ValidateDump(strings.NewReader(valid), turso.ValidateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Valid() || report.Statements != 7 {
+		t.Fatalf("expected a valid dump of 7 statements, got %d statements and: %v", report.Statements, report.Problems)
+	}
+
+	invalid := "BEGIN TRANSACTION;\nATTACH 'other.db' AS other;\nINSERT INTO t VALUES('unterminated);\n"
+	report, err = turso.ValidateDump(strings.NewReader(invalid), turso.ValidateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`syntax: line 2: unexpected statement "ATTACH" in a dump`,
+		"syntax: line 3: unterminated '",
+		"syntax: line 3: statement is not terminated by a semicolon",
+		"syntax: line 1: transaction is never committed",
+	}
+	if len(report.Problems) != len(want) {
+		t.Fatalf("expected %d problems, got: %v", len(want), report.Problems)
+	}
+	for i, problem := range report.Problems {
+		if problem.String() != want[i] {
+			t.Fatalf("expected problem %q, got %q", want[i], problem.String())
+		}
+	}
+
+	var validationErr *turso.ValidationError
+	if err := report.Err(); !errors.As(err, &validationErr) {
+		t.Fatalf("expected a ValidationError, got: %v", err)
+	}
+
+	report, err = turso.ValidateDump(bytes.NewReader(sqliteFile(4096, 1, false)), turso.ValidateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := checks(report); !slices.Equal(got, []turso.ValidationCheck{turso.CheckHeader}) {
+		t.Fatalf("expected a database passed as a dump to be rejected, got: %v", report.Problems)
+	}
+}
+
+func Test_Databases_ValidateSeed(t *testing.T) {
+	plans := tursotest.DefaultPlans()
+	tiny := turso.Plan{Name: "tiny"}
+	tiny.Quotas.Storage = 4096
+	small := turso.Plan{Name: "small"}
+	small.Quotas.Storage = 12288
+	server := tursotest.NewServer(tursotest.WithPlans(append(plans, tiny, small)))
+	defer server.Close()
+	client := server.Client(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "my-db.sqlite")
+	if err := os.WriteFile(path, sqliteFile(4096, 2, false), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	report, err := client.Databases.ValidateSeed(context.TODO(), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Valid() || report.JournalMode != "rollback" || report.PageSize != 4096 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if offset, _ := file.Seek(0, io.SeekCurrent); offset != 0 {
+		t.Fatalf("expected the file offset to be restored, got %d", offset)
+	}
+
+	// The file fits in the quota of the small plan, but not next to the
+	// storage already used by another database.
+	if err := server.SetPlan(tursotest.DefaultOrg, "small"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Groups.Create(context.TODO(), "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.Create(context.TODO(), "other-db", "", "", "", "default", "", false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Databases.Seed(context.TODO(), "other-db", file); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	report, err = client.Databases.ValidateSeed(context.TODO(), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := checks(report); !slices.Equal(got, []turso.ValidationCheck{turso.CheckSize}) {
+		t.Fatalf("expected the storage in use to count against the quota, got: %v", report.Problems)
+	}
+
+	if err := os.WriteFile(path+"-wal", []byte("pending"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.SetPlan(tursotest.DefaultOrg, "tiny"); err != nil {
+		t.Fatal(err)
+	}
+	report, err = client.Databases.ValidateSeed(context.TODO(), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := checks(report); !slices.Equal(got, []turso.ValidationCheck{turso.CheckSize, turso.CheckJournalMode}) {
+		t.Fatalf("expected size and journal problems, got: %v", report.Problems)
+	}
+
+	server.InjectFailure(tursotest.Failure{Path: "/v1/organizations/*/subscription", Status: http.StatusForbidden, Times: 1})
+	report, err = client.Databases.ValidateSeed(context.TODO(), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := checks(report); !slices.Equal(got, []turso.ValidationCheck{turso.CheckJournalMode}) {
+		t.Fatalf("expected the size not to be checked without a quota, got: %v", report.Problems)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].Check != turso.CheckSize {
+		t.Fatalf("expected the size check to be reported as skipped, got: %v", report.Skipped)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if _, err := client.Databases.ValidateSeed(ctx, file); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+}
+
+func Test_Databases_ValidateSeed_PlanQuotaInBytes(t *testing.T) {
+	// Responses shaped like the Platform API's, where plan storage quotas and
+	// storage usage are both in bytes.
+	var used string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/organizations/my-org/subscription":
+			w.Write([]byte(`{"subscription":{"plan":"starter","timeline":"monthly","overages":false}}`))
+		case "/v1/plans":
+			w.Write([]byte(`{"plans":[{"name":"starter","price":"0","quotas":{"rowsRead":1000000000,"rowsWritten":25000000,"databases":500,"locations":3,"storage":9000000000,"groups":1,"bytesSynced":3000000000}}]}`))
+		case "/v1/organizations/my-org/usage":
+			w.Write([]byte(`{"organization":{"uuid":"1234","usage":{"rows_read":0,"rows_written":0,"storage_bytes":` + used + `}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := turso.New("my-token", "my-org", turso.WithBaseUrl(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "my-db.sqlite")
+	if err := os.WriteFile(path, sqliteFile(4096, 4, false), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	used = "2500000000"
+	report, err := client.Databases.ValidateSeed(context.TODO(), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Valid() || len(report.Skipped) != 0 {
+		t.Fatalf("expected 16KiB to fit in the 6.5GB left, got: %v %v", report.Problems, report.Skipped)
+	}
+
+	used = "8999990000"
+	report, err = client.Databases.ValidateSeed(context.TODO(), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := checks(report); !slices.Equal(got, []turso.ValidationCheck{turso.CheckSize}) {
+		t.Fatalf("expected 16KiB not to fit in the 10kB left, got: %v", report.Problems)
+	}
+}
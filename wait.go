@@ -0,0 +1,206 @@
+package turso
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWaitTimeout is the timeout of WaitReady when none is set.
+const DefaultWaitTimeout = 5 * time.Minute
+
+// WaitReadyOptions configures DatabasesClient.WaitReady.
+type WaitReadyOptions struct {
+	// Timeout bounds the whole wait. Defaults to DefaultWaitTimeout.
+	Timeout time.Duration
+	// MinBackoff is the delay before polling an instance again after a failed
+	// attempt. It doubles with every following attempt up to MaxBackoff.
+	// They default to 250ms and 5s.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// NotFoundGrace is how long a database or instance that is not found is
+	// polled for, since a database that was just created may not be found
+	// yet. Defaults to 5s.
+	NotFoundGrace time.Duration
+	// Progress, when set, is called after every attempt to reach an instance.
+	// Calls are not concurrent.
+	Progress func(InstanceProgress)
+}
+
+// InstanceProgress reports an attempt to reach an instance.
+type InstanceProgress struct {
+	Instance Instance
+	// Attempt is the attempt number, starting at 1.
+	Attempt int
+	// Ready reports whether the instance is ready. Otherwise Err is the reason
+	// it is not.
+	Ready bool
+	Err   error
+}
+
+// WaitTimeoutError is returned by WaitReady when instances are not ready
+// before the timeout. It wraps the error of the context, usually
+// context.DeadlineExceeded. Use errors.As to access it.
+type WaitTimeoutError struct {
+	Database string
+	// Locations lists the locations of the instances that never became ready,
+	// sorted. It is empty when the instances of the database could not be
+	// listed.
+	Locations []string
+	// LastErr is the last error returned while polling, if any.
+	LastErr error
+	Err     error
+}
+
+func (e *WaitTimeoutError) Error() string {
+	msg := fmt.Sprintf("database %s not ready", e.Database)
+	if len(e.Locations) > 0 {
+		msg += " in " + strings.Join(e.Locations, ", ")
+	}
+	msg += ": " + e.Err.Error()
+	if e.LastErr != nil {
+		msg += " (last error: " + e.LastErr.Error() + ")"
+	}
+	return msg
+}
+
+func (e *WaitTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// WaitReady waits until every instance of the database with the given name is
+// ready, e.g. after Create, Seed or Transfer. Instances are polled
+// concurrently with backoff until they are ready or the timeout expires, in
+// which case a *WaitTimeoutError is returned. Authorization errors are
+// returned immediately, and an error matching ErrNotFound once the database
+// or one of its instances, e.g. a replica deleted while waiting, is still not
+// found after NotFoundGrace.
+func (c *DatabasesClient) WaitReady(ctx context.Context, name string, opts WaitReadyOptions) error {
+	ctx = WithOperation(ctx, "databases.wait_ready")
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultWaitTimeout
+	}
+	policy := RetryPolicy{MinBackoff: opts.MinBackoff, MaxBackoff: opts.MaxBackoff}
+	if policy.MinBackoff <= 0 {
+		policy.MinBackoff = 250 * time.Millisecond
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = 5 * time.Second
+	}
+	if opts.NotFoundGrace <= 0 {
+		opts.NotFoundGrace = 5 * time.Second
+	}
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	// The instances of a database that was just created or transferred may
+	// not be listed yet.
+	var instances []Instance
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		instances, lastErr = c.client.Instances.List(ctx, name)
+		if lastErr != nil && isPermanentWaitErr(lastErr) {
+			return lastErr
+		}
+		if errors.Is(lastErr, ErrNotFound) && time.Since(start) >= opts.NotFoundGrace {
+			return lastErr
+		}
+		if len(instances) > 0 {
+			break
+		}
+		if err := sleep(ctx, policy.delay(attempt)); err != nil {
+			return &WaitTimeoutError{Database: name, LastErr: lastErr, Err: err}
+		}
+	}
+
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+	w := &instanceWaiter{client: c.client, database: name, policy: policy, progress: opts.Progress, stop: stop, start: start, notFoundGrace: opts.NotFoundGrace}
+	var wg sync.WaitGroup
+	for _, instance := range instances {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.wait(ctx, instance)
+		}()
+	}
+	wg.Wait()
+
+	if w.permanentErr != nil {
+		return w.permanentErr
+	}
+	if len(w.pending) > 0 {
+		sort.Strings(w.pending)
+		return &WaitTimeoutError{Database: name, Locations: w.pending, LastErr: w.lastErr, Err: ctx.Err()}
+	}
+	return nil
+}
+
+// instanceWaiter polls the instances of a database.
+type instanceWaiter struct {
+	client   *Client
+	database string
+	policy   RetryPolicy
+	progress func(InstanceProgress)
+	// stop cancels the other instances on a permanent error.
+	stop context.CancelFunc
+	// An instance that is not found after notFoundGrace since start is
+	// reported as a permanent error.
+	start         time.Time
+	notFoundGrace time.Duration
+
+	mu           sync.Mutex
+	pending      []string
+	lastErr      error
+	permanentErr error
+}
+
+func (w *instanceWaiter) wait(ctx context.Context, instance Instance) {
+	for attempt := 1; ; attempt++ {
+		err := w.client.Instances.Wait(ctx, w.database, instance.Name)
+		w.report(InstanceProgress{Instance: instance, Attempt: attempt, Ready: err == nil, Err: err})
+		if err == nil {
+			return
+		}
+
+		w.mu.Lock()
+		if isPermanentWaitErr(err) || errors.Is(err, ErrNotFound) && time.Since(w.start) >= w.notFoundGrace {
+			if w.permanentErr == nil {
+				w.permanentErr = err
+			}
+			w.mu.Unlock()
+			w.stop()
+			return
+		}
+		if ctx.Err() == nil {
+			w.lastErr = err
+		}
+		w.mu.Unlock()
+
+		if sleep(ctx, w.policy.delay(attempt)) != nil {
+			w.mu.Lock()
+			w.pending = append(w.pending, instance.Region)
+			w.mu.Unlock()
+			return
+		}
+	}
+}
+
+func (w *instanceWaiter) report(progress InstanceProgress) {
+	if w.progress == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.progress(progress)
+}
+
+// isPermanentWaitErr reports whether polling again cannot succeed.
+func isPermanentWaitErr(err error) bool {
+	return errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrForbidden) || errors.Is(err, ErrNotMember)
+}
@@ -0,0 +1,124 @@
+package turso_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alehechka/turso-go"
+	"github.com/alehechka/turso-go/tursotest"
+)
+
+func Test_Databases_WaitReady(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+	ctx := context.TODO()
+
+	if err := client.Groups.Create(ctx, "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Groups.AddLocation(ctx, "default", "fra"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.Create(ctx, "my-db", "", "", "", "default", "", false, nil); err != nil {
+		t.Fatal(err)
+	}
+	db, err := client.Databases.GetWithInstances(ctx, "my-db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var replica turso.Instance
+	for _, instance := range db.Instances {
+		if instance.Region == "fra" {
+			replica = instance
+		}
+	}
+	waitPath := "/v1/organizations/" + tursotest.DefaultOrg + "/databases/my-db/instances/" + replica.Name + "/wait"
+
+	server.InjectFailure(tursotest.Failure{Path: waitPath, Status: http.StatusServiceUnavailable, Times: 2})
+	var ready []string
+	opts := turso.WaitReadyOptions{
+		Timeout:    time.Second,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+		Progress: func(p turso.InstanceProgress) {
+			if p.Ready {
+				ready = append(ready, p.Instance.Region)
+			} else if p.Err == nil {
+				t.Errorf("expected an error for an instance that is not ready")
+			}
+		},
+	}
+	if err := client.Databases.WaitReady(ctx, "my-db", opts); err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(ready)
+	if !slices.Equal(ready, []string{"ams", "fra"}) {
+		t.Fatalf("expected every instance to become ready, got: %v", ready)
+	}
+
+	server.InjectFailure(tursotest.Failure{Path: waitPath, Status: http.StatusServiceUnavailable})
+	opts.Timeout, opts.Progress = 50*time.Millisecond, nil
+	err = client.Databases.WaitReady(ctx, "my-db", opts)
+	var timeoutErr *turso.WaitTimeoutError
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a WaitTimeoutError, got: %v", err)
+	}
+	if !slices.Equal(timeoutErr.Locations, []string{"fra"}) || timeoutErr.LastErr == nil {
+		t.Fatalf("expected fra to never become ready, got: %v", err)
+	}
+}
+
+func Test_Databases_WaitReady_NotFound(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+
+	start := time.Now()
+	err := client.Databases.WaitReady(context.TODO(), "missing", turso.WaitReadyOptions{
+		MinBackoff:    time.Millisecond,
+		MaxBackoff:    time.Millisecond,
+		NotFoundGrace: 20 * time.Millisecond,
+	})
+	if !errors.Is(err, turso.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected a missing database to be reported quickly, took %s", elapsed)
+	}
+}
+
+func Test_Databases_WaitReady_InstanceNotFound(t *testing.T) {
+	server := tursotest.NewServer()
+	defer server.Close()
+	client := server.Client(t)
+	ctx := context.TODO()
+
+	if err := client.Groups.Create(ctx, "default", "ams", "latest"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Databases.Create(ctx, "my-db", "", "", "", "default", "", false, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The instance is deleted while it is waited for.
+	server.InjectFailure(tursotest.Failure{Path: "/v1/organizations/*/databases/my-db/instances/*/wait", Status: http.StatusNotFound})
+	start := time.Now()
+	err := client.Databases.WaitReady(ctx, "my-db", turso.WaitReadyOptions{
+		Timeout:       5 * time.Second,
+		MinBackoff:    time.Millisecond,
+		MaxBackoff:    time.Millisecond,
+		NotFoundGrace: 20 * time.Millisecond,
+	})
+	var timeoutErr *turso.WaitTimeoutError
+	if !errors.Is(err, turso.ErrNotFound) || errors.As(err, &timeoutErr) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected a missing instance to be reported quickly, took %s", elapsed)
+	}
+}